@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"mime"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	usecase "personal-finance-tracker/UseCase"
+)
+
+type AttachmentHandler struct {
+	attachmentUsecase *usecase.AttachmentUsecase
+}
+
+func NewAttachmentHandler(attachmentUsecase *usecase.AttachmentUsecase) *AttachmentHandler {
+	return &AttachmentHandler{
+		attachmentUsecase: attachmentUsecase,
+	}
+}
+
+// Upload accepts a multipart form with "file", "parent_type" and "parent_id"
+func (h *AttachmentHandler) Upload(c *gin.Context) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+		return
+	}
+	if fileHeader.Size > usecase.MaxAttachmentSize {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "file too large"})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer file.Close()
+
+	attachment, err := h.attachmentUsecase.Upload(c.GetString("user_id"), c.PostForm("parent_type"), c.PostForm("parent_id"), fileHeader.Filename, file)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, attachment)
+}
+
+// ListAttachments handles GET /attachments?parent_type=&parent_id=
+func (h *AttachmentHandler) ListAttachments(c *gin.Context) {
+	page, err := bindPageRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	attachments, err := h.attachmentUsecase.ListAttachments(c.GetString("user_id"), c.Query("parent_type"), c.Query("parent_id"), page)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, attachments)
+}
+
+func (h *AttachmentHandler) GetAttachment(c *gin.Context) {
+	attachment, err := h.attachmentUsecase.GetAttachment(c.GetString("user_id"), c.Param("id"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, attachment)
+}
+
+func (h *AttachmentHandler) Download(c *gin.Context) {
+	h.serve(c, false)
+}
+
+func (h *AttachmentHandler) Thumbnail(c *gin.Context) {
+	h.serve(c, true)
+}
+
+func (h *AttachmentHandler) serve(c *gin.Context, thumbnail bool) {
+	attachment, reader, err := h.attachmentUsecase.Open(c.GetString("user_id"), c.Param("id"), thumbnail)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	defer reader.Close()
+
+	if thumbnail {
+		c.DataFromReader(http.StatusOK, -1, "image/jpeg", reader, nil)
+		return
+	}
+
+	headers := map[string]string{
+		"Content-Disposition":    mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename}),
+		"X-Content-Type-Options": "nosniff",
+	}
+	c.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, reader, headers)
+}
+
+func (h *AttachmentHandler) DeleteAttachment(c *gin.Context) {
+	if err := h.attachmentUsecase.DeleteAttachment(c.GetString("user_id"), c.Param("id")); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *AttachmentHandler) GetUsage(c *gin.Context) {
+	usage, err := h.attachmentUsecase.GetUsage(c.GetString("user_id"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, usage)
+}
@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"personal-finance-tracker/domain/entities"
+	usecase "personal-finance-tracker/UseCase"
+)
+
+// defaultUpcomingDays is the window of GET /bills/upcoming without ?days=
+const defaultUpcomingDays = 30
+
+type BillHandler struct {
+	billUsecase *usecase.BillUsecase
+}
+
+func NewBillHandler(billUsecase *usecase.BillUsecase) *BillHandler {
+	return &BillHandler{
+		billUsecase: billUsecase,
+	}
+}
+
+func (h *BillHandler) CreateBill(c *gin.Context) {
+	var bill entities.Bill
+
+	if err := c.ShouldBindJSON(&bill); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	createdBill, err := h.billUsecase.CreateBill(c.GetString("user_id"), &bill)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, createdBill)
+}
+
+func (h *BillHandler) ListBills(c *gin.Context) {
+	page, err := bindPageRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	bills, err := h.billUsecase.ListBills(c.GetString("user_id"), page)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, bills)
+}
+
+func (h *BillHandler) GetBill(c *gin.Context) {
+	bill, err := h.billUsecase.GetBill(c.GetString("user_id"), c.Param("id"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, bill)
+}
+
+func (h *BillHandler) UpdateBill(c *gin.Context) {
+	// active is optional here, so a PUT that leaves it out keeps the bill on
+	var input struct {
+		entities.Bill
+		Active *bool `json:"active"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updatedBill, err := h.billUsecase.UpdateBill(c.GetString("user_id"), c.Param("id"), &input.Bill, input.Active)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, updatedBill)
+}
+
+func (h *BillHandler) DeleteBill(c *gin.Context) {
+	if err := h.billUsecase.DeleteBill(c.GetString("user_id"), c.Param("id")); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *BillHandler) RecordPayment(c *gin.Context) {
+	var payment entities.BillPayment
+
+	if err := c.ShouldBindJSON(&payment); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	bill, err := h.billUsecase.RecordPayment(c.GetString("user_id"), c.Param("id"), payment)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, bill)
+}
+
+func (h *BillHandler) RemovePayment(c *gin.Context) {
+	bill, err := h.billUsecase.RemovePayment(c.GetString("user_id"), c.Param("id"), c.Param("paymentId"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, bill)
+}
+
+// GetUpcoming handles GET /bills/upcoming?days=30
+func (h *BillHandler) GetUpcoming(c *gin.Context) {
+	days := defaultUpcomingDays
+	if value := c.Query("days"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid days"})
+			return
+		}
+		days = parsed
+	}
+
+	occurrences, err := h.billUsecase.GetUpcoming(c.GetString("user_id"), days)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"occurrences": occurrences})
+}
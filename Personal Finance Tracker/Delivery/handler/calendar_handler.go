@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	usecase "personal-finance-tracker/UseCase"
+)
+
+type CalendarHandler struct {
+	calendarUsecase *usecase.CalendarUsecase
+}
+
+func NewCalendarHandler(calendarUsecase *usecase.CalendarUsecase) *CalendarHandler {
+	return &CalendarHandler{
+		calendarUsecase: calendarUsecase,
+	}
+}
+
+// CreateFeedToken issues a new feed URL and revokes the previous one
+func (h *CalendarHandler) CreateFeedToken(c *gin.Context) {
+	token, err := h.calendarUsecase.CreateFeedToken(c.GetString("user_id"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"token": token,
+		"url":   "/calendar/" + token + ".ics",
+	})
+}
+
+func (h *CalendarHandler) RevokeFeedToken(c *gin.Context) {
+	if err := h.calendarUsecase.RevokeFeedToken(c.GetString("user_id")); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetFeed handles GET /calendar/:file where file is "<token>.ics". The
+// token in the URL is the only credential, so calendar apps can subscribe.
+func (h *CalendarHandler) GetFeed(c *gin.Context) {
+	token, ok := strings.CutSuffix(c.Param("file"), ".ics")
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "calendar feed not found"})
+		return
+	}
+
+	feed, err := h.calendarUsecase.RenderFeed(token)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Cache-Control", "private, max-age=900")
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", feed)
+}
@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"personal-finance-tracker/domain/entities"
+	usecase "personal-finance-tracker/UseCase"
+)
+
+type DebtHandler struct {
+	debtUsecase *usecase.DebtUsecase
+}
+
+func NewDebtHandler(debtUsecase *usecase.DebtUsecase) *DebtHandler {
+	return &DebtHandler{
+		debtUsecase: debtUsecase,
+	}
+}
+
+func (h *DebtHandler) CreateDebt(c *gin.Context) {
+	// current_balance is optional, so an explicit 0 can be told apart from none
+	var input struct {
+		entities.Debt
+		CurrentBalance *float64 `json:"current_balance"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	createdDebt, err := h.debtUsecase.CreateDebt(c.GetString("user_id"), &input.Debt, input.CurrentBalance)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, createdDebt)
+}
+
+func (h *DebtHandler) ListDebts(c *gin.Context) {
+	page, err := bindPageRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	debts, err := h.debtUsecase.ListDebts(c.GetString("user_id"), page)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, debts)
+}
+
+func (h *DebtHandler) GetDebt(c *gin.Context) {
+	debt, err := h.debtUsecase.GetDebt(c.GetString("user_id"), c.Param("id"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, debt)
+}
+
+func (h *DebtHandler) UpdateDebt(c *gin.Context) {
+	// current_balance is optional, so an explicit 0 can be told apart from none
+	var input struct {
+		entities.Debt
+		CurrentBalance *float64 `json:"current_balance"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updatedDebt, err := h.debtUsecase.UpdateDebt(c.GetString("user_id"), c.Param("id"), &input.Debt, input.CurrentBalance)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, updatedDebt)
+}
+
+func (h *DebtHandler) DeleteDebt(c *gin.Context) {
+	if err := h.debtUsecase.DeleteDebt(c.GetString("user_id"), c.Param("id")); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *DebtHandler) AddExtraPayment(c *gin.Context) {
+	var payment entities.ExtraPayment
+
+	if err := c.ShouldBindJSON(&payment); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	debt, err := h.debtUsecase.AddExtraPayment(c.GetString("user_id"), c.Param("id"), payment)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, debt)
+}
+
+func (h *DebtHandler) RemoveExtraPayment(c *gin.Context) {
+	debt, err := h.debtUsecase.RemoveExtraPayment(c.GetString("user_id"), c.Param("id"), c.Param("paymentId"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, debt)
+}
+
+func (h *DebtHandler) GetAmortization(c *gin.Context) {
+	schedule, err := h.debtUsecase.GetAmortization(c.GetString("user_id"), c.Param("id"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, schedule)
+}
+
+// SimulatePayoff handles GET /debts/payoff?strategy=&extra=, where extra is
+// the monthly amount available on top of the minimum payments
+func (h *DebtHandler) SimulatePayoff(c *gin.Context) {
+	extra := 0.0
+	if value := c.Query("extra"); value != "" {
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid extra amount"})
+			return
+		}
+		extra = parsed
+	}
+
+	plans, err := h.debtUsecase.SimulatePayoff(c.GetString("user_id"), c.Query("strategy"), extra)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"plans": plans})
+}
@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	usecase "personal-finance-tracker/UseCase"
+)
+
+// maxRateFileSize caps uploaded rate files; the full ECB history is a few MB
+const maxRateFileSize = 32 << 20
+
+type ExchangeRateHandler struct {
+	rateUsecase *usecase.ExchangeRateUsecase
+}
+
+func NewExchangeRateHandler(rateUsecase *usecase.ExchangeRateUsecase) *ExchangeRateHandler {
+	return &ExchangeRateHandler{
+		rateUsecase: rateUsecase,
+	}
+}
+
+// ImportRates accepts an ECB csv or xml file in the "file" form field.
+// The format comes from ?format= or the file extension.
+func (h *ExchangeRateHandler) ImportRates(c *gin.Context) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+		return
+	}
+	if fileHeader.Size > maxRateFileSize {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "file too large"})
+		return
+	}
+
+	format := c.Query("format")
+	if format == "" {
+		format = strings.TrimPrefix(filepath.Ext(fileHeader.Filename), ".")
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer file.Close()
+
+	count, err := h.rateUsecase.ImportRates(format, file)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"imported": count})
+}
+
+func (h *ExchangeRateHandler) ListRates(c *gin.Context) {
+	page, err := bindPageRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	from, err := parseDateQuery(c, "from")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	to, err := parseDateQuery(c, "to")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rates, err := h.rateUsecase.ListRates(c.Query("quote"), from, to, page)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, rates)
+}
+
+// Convert handles GET /exchange-rates/convert?amount=&from=&to=&date=.
+// to defaults to the user's currency and date to today.
+func (h *ExchangeRateHandler) Convert(c *gin.Context) {
+	amount, err := strconv.ParseFloat(c.Query("amount"), 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid amount"})
+		return
+	}
+	date, err := parseDateQuery(c, "date")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if date.IsZero() {
+		date = time.Now().UTC().Truncate(24 * time.Hour)
+	}
+
+	conversion, err := h.rateUsecase.Convert(c.GetString("user_id"), amount, c.Query("from"), c.Query("to"), date)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, conversion)
+}
@@ -0,0 +1,195 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"personal-finance-tracker/domain/entities"
+	usecase "personal-finance-tracker/UseCase"
+)
+
+type ExpenseGroupHandler struct {
+	expenseGroupUsecase *usecase.ExpenseGroupUsecase
+}
+
+func NewExpenseGroupHandler(expenseGroupUsecase *usecase.ExpenseGroupUsecase) *ExpenseGroupHandler {
+	return &ExpenseGroupHandler{
+		expenseGroupUsecase: expenseGroupUsecase,
+	}
+}
+
+type settlementRequest struct {
+	From   primitive.ObjectID `json:"from" binding:"required"`
+	To     primitive.ObjectID `json:"to" binding:"required"`
+	Amount float64            `json:"amount" binding:"required"`
+	Date   time.Time          `json:"date"`
+}
+
+func (h *ExpenseGroupHandler) CreateGroup(c *gin.Context) {
+	var group entities.ExpenseGroup
+
+	if err := c.ShouldBindJSON(&group); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	createdGroup, err := h.expenseGroupUsecase.CreateGroup(c.GetString("user_id"), &group)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, createdGroup)
+}
+
+func (h *ExpenseGroupHandler) ListGroups(c *gin.Context) {
+	page, err := bindPageRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	groups, err := h.expenseGroupUsecase.ListGroups(c.GetString("user_id"), page)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, groups)
+}
+
+func (h *ExpenseGroupHandler) GetGroup(c *gin.Context) {
+	group, err := h.expenseGroupUsecase.GetGroup(c.GetString("user_id"), c.Param("id"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, group)
+}
+
+func (h *ExpenseGroupHandler) UpdateGroup(c *gin.Context) {
+	var group entities.ExpenseGroup
+
+	if err := c.ShouldBindJSON(&group); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updatedGroup, err := h.expenseGroupUsecase.UpdateGroup(c.GetString("user_id"), c.Param("id"), &group)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, updatedGroup)
+}
+
+func (h *ExpenseGroupHandler) DeleteGroup(c *gin.Context) {
+	if err := h.expenseGroupUsecase.DeleteGroup(c.GetString("user_id"), c.Param("id")); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *ExpenseGroupHandler) AddParticipant(c *gin.Context) {
+	var participant entities.Participant
+
+	if err := c.ShouldBindJSON(&participant); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	group, err := h.expenseGroupUsecase.AddParticipant(c.GetString("user_id"), c.Param("id"), participant)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, group)
+}
+
+func (h *ExpenseGroupHandler) RemoveParticipant(c *gin.Context) {
+	group, err := h.expenseGroupUsecase.RemoveParticipant(c.GetString("user_id"), c.Param("id"), c.Param("participantId"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, group)
+}
+
+func (h *ExpenseGroupHandler) AddExpense(c *gin.Context) {
+	var expense entities.GroupExpense
+
+	if err := c.ShouldBindJSON(&expense); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	createdExpense, err := h.expenseGroupUsecase.AddExpense(c.GetString("user_id"), c.Param("id"), &expense)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, createdExpense)
+}
+
+func (h *ExpenseGroupHandler) ListExpenses(c *gin.Context) {
+	page, err := bindPageRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	expenses, err := h.expenseGroupUsecase.ListExpenses(c.GetString("user_id"), c.Param("id"), page)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, expenses)
+}
+
+func (h *ExpenseGroupHandler) DeleteExpense(c *gin.Context) {
+	if err := h.expenseGroupUsecase.DeleteExpense(c.GetString("user_id"), c.Param("id"), c.Param("expenseId")); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RecordSettlement handles POST /expense-groups/:id/settlements, recording
+// that one participant paid another back
+func (h *ExpenseGroupHandler) RecordSettlement(c *gin.Context) {
+	var request settlementRequest
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	settlement, err := h.expenseGroupUsecase.RecordSettlement(c.GetString("user_id"), c.Param("id"), request.From, request.To, request.Amount, request.Date)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, settlement)
+}
+
+func (h *ExpenseGroupHandler) GetBalances(c *gin.Context) {
+	balances, err := h.expenseGroupUsecase.GetBalances(c.GetString("user_id"), c.Param("id"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, balances)
+}
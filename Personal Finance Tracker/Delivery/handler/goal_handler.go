@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"personal-finance-tracker/domain/entities"
+	usecase "personal-finance-tracker/UseCase"
+)
+
+type GoalHandler struct {
+	goalUsecase *usecase.GoalUsecase
+}
+
+func NewGoalHandler(goalUsecase *usecase.GoalUsecase) *GoalHandler {
+	return &GoalHandler{
+		goalUsecase: goalUsecase,
+	}
+}
+
+func (h *GoalHandler) CreateGoal(c *gin.Context) {
+	var goal entities.Goal
+
+	if err := c.ShouldBindJSON(&goal); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	createdGoal, err := h.goalUsecase.CreateGoal(c.GetString("user_id"), &goal)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, createdGoal)
+}
+
+func (h *GoalHandler) ListGoals(c *gin.Context) {
+	page, err := bindPageRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	goals, err := h.goalUsecase.ListGoals(c.GetString("user_id"), c.Query("household_id"), page)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, goals)
+}
+
+func (h *GoalHandler) GetGoal(c *gin.Context) {
+	goal, err := h.goalUsecase.GetGoal(c.GetString("user_id"), c.Param("id"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, goal)
+}
+
+func (h *GoalHandler) UpdateGoal(c *gin.Context) {
+	var goal entities.Goal
+
+	if err := c.ShouldBindJSON(&goal); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updatedGoal, err := h.goalUsecase.UpdateGoal(c.GetString("user_id"), c.Param("id"), &goal)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, updatedGoal)
+}
+
+func (h *GoalHandler) DeleteGoal(c *gin.Context) {
+	if err := h.goalUsecase.DeleteGoal(c.GetString("user_id"), c.Param("id")); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *GoalHandler) AddContribution(c *gin.Context) {
+	var contribution entities.Contribution
+
+	if err := c.ShouldBindJSON(&contribution); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	goal, err := h.goalUsecase.AddContribution(c.GetString("user_id"), c.Param("id"), contribution)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, goal)
+}
+
+func (h *GoalHandler) RemoveContribution(c *gin.Context) {
+	goal, err := h.goalUsecase.RemoveContribution(c.GetString("user_id"), c.Param("id"), c.Param("contributionId"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, goal)
+}
+
+func (h *GoalHandler) GetProjection(c *gin.Context) {
+	projection, err := h.goalUsecase.GetProjection(c.GetString("user_id"), c.Param("id"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, projection)
+}
@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const dateLayout = "2006-01-02"
+
+// errorStatus maps use case errors to HTTP status codes
+func errorStatus(err error) int {
+	message := err.Error()
+	switch {
+	case strings.HasSuffix(message, "not found"):
+		return http.StatusNotFound
+	case strings.HasPrefix(message, "forbidden"):
+		return http.StatusForbidden
+	case strings.HasPrefix(message, "Database error"), strings.HasPrefix(message, "Failed"):
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+// parseDateQuery reads an optional YYYY-MM-DD query parameter
+func parseDateQuery(c *gin.Context, name string) (time.Time, error) {
+	value := c.Query(name)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	date, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return time.Time{}, errors.New("invalid " + name + ": expected YYYY-MM-DD")
+	}
+	return date, nil
+}
@@ -0,0 +1,202 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"personal-finance-tracker/domain/entities"
+	usecase "personal-finance-tracker/UseCase"
+)
+
+type HouseholdHandler struct {
+	householdUsecase *usecase.HouseholdUsecase
+}
+
+func NewHouseholdHandler(householdUsecase *usecase.HouseholdUsecase) *HouseholdHandler {
+	return &HouseholdHandler{
+		householdUsecase: householdUsecase,
+	}
+}
+
+type invitationRequest struct {
+	Email string `json:"email" binding:"required"`
+	Role  string `json:"role" binding:"required"`
+}
+
+type acceptInvitationRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type memberRoleRequest struct {
+	Role string `json:"role" binding:"required"`
+}
+
+func (h *HouseholdHandler) CreateHousehold(c *gin.Context) {
+	var household entities.Household
+
+	if err := c.ShouldBindJSON(&household); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	createdHousehold, err := h.householdUsecase.CreateHousehold(c.GetString("user_id"), &household)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, createdHousehold)
+}
+
+func (h *HouseholdHandler) ListHouseholds(c *gin.Context) {
+	page, err := bindPageRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	households, err := h.householdUsecase.ListHouseholds(c.GetString("user_id"), page)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, households)
+}
+
+func (h *HouseholdHandler) GetHousehold(c *gin.Context) {
+	household, err := h.householdUsecase.GetHousehold(c.GetString("user_id"), c.Param("id"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, household)
+}
+
+func (h *HouseholdHandler) UpdateHousehold(c *gin.Context) {
+	var household entities.Household
+
+	if err := c.ShouldBindJSON(&household); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updatedHousehold, err := h.householdUsecase.UpdateHousehold(c.GetString("user_id"), c.Param("id"), &household)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, updatedHousehold)
+}
+
+func (h *HouseholdHandler) DeleteHousehold(c *gin.Context) {
+	if err := h.householdUsecase.DeleteHousehold(c.GetString("user_id"), c.Param("id")); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *HouseholdHandler) InviteMember(c *gin.Context) {
+	var req invitationRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	invitation, err := h.householdUsecase.InviteMember(c.GetString("user_id"), c.Param("id"), req.Email, req.Role)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, invitation)
+}
+
+func (h *HouseholdHandler) ListInvitations(c *gin.Context) {
+	invitations, err := h.householdUsecase.ListInvitations(c.GetString("user_id"), c.Param("id"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"invitations": invitations})
+}
+
+func (h *HouseholdHandler) RevokeInvitation(c *gin.Context) {
+	if err := h.householdUsecase.RevokeInvitation(c.GetString("user_id"), c.Param("id"), c.Param("invitationId")); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// PreviewInvitation handles GET /households/invitations/accept?token=, the
+// link in the invitation email. It is public and only describes the
+// invitation; accepting it takes the POST below, signed in.
+func (h *HouseholdHandler) PreviewInvitation(c *gin.Context) {
+	preview, err := h.householdUsecase.PreviewInvitation(c.Query("token"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, preview)
+}
+
+// AcceptInvitation handles POST /households/invitations/accept with the
+// token from the invitation email
+func (h *HouseholdHandler) AcceptInvitation(c *gin.Context) {
+	var req acceptInvitationRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	household, err := h.householdUsecase.AcceptInvitation(c.GetString("user_id"), req.Token)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, household)
+}
+
+func (h *HouseholdHandler) UpdateMemberRole(c *gin.Context) {
+	var req memberRoleRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	household, err := h.householdUsecase.UpdateMemberRole(c.GetString("user_id"), c.Param("id"), c.Param("userId"), req.Role)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, household)
+}
+
+func (h *HouseholdHandler) RemoveMember(c *gin.Context) {
+	household, err := h.householdUsecase.RemoveMember(c.GetString("user_id"), c.Param("id"), c.Param("userId"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	// The last member left and the household is gone
+	if household == nil {
+		c.Status(http.StatusNoContent)
+		return
+	}
+
+	c.JSON(http.StatusOK, household)
+}
@@ -0,0 +1,265 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"personal-finance-tracker/domain/entities"
+	usecase "personal-finance-tracker/UseCase"
+)
+
+// maxPriceFileSize caps uploaded price history files
+const maxPriceFileSize = 32 << 20
+
+type InvestmentHandler struct {
+	investmentUsecase *usecase.InvestmentUsecase
+}
+
+func NewInvestmentHandler(investmentUsecase *usecase.InvestmentUsecase) *InvestmentHandler {
+	return &InvestmentHandler{
+		investmentUsecase: investmentUsecase,
+	}
+}
+
+func (h *InvestmentHandler) CreateAccount(c *gin.Context) {
+	var account entities.InvestmentAccount
+
+	if err := c.ShouldBindJSON(&account); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	createdAccount, err := h.investmentUsecase.CreateAccount(c.GetString("user_id"), &account)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, createdAccount)
+}
+
+func (h *InvestmentHandler) ListAccounts(c *gin.Context) {
+	page, err := bindPageRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	accounts, err := h.investmentUsecase.ListAccounts(c.GetString("user_id"), page)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, accounts)
+}
+
+func (h *InvestmentHandler) GetAccount(c *gin.Context) {
+	account, err := h.investmentUsecase.GetAccount(c.GetString("user_id"), c.Param("id"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, account)
+}
+
+func (h *InvestmentHandler) UpdateAccount(c *gin.Context) {
+	var account entities.InvestmentAccount
+
+	if err := c.ShouldBindJSON(&account); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updatedAccount, err := h.investmentUsecase.UpdateAccount(c.GetString("user_id"), c.Param("id"), &account)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, updatedAccount)
+}
+
+func (h *InvestmentHandler) DeleteAccount(c *gin.Context) {
+	if err := h.investmentUsecase.DeleteAccount(c.GetString("user_id"), c.Param("id")); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *InvestmentHandler) AddEvent(c *gin.Context) {
+	var event entities.InvestmentEvent
+
+	if err := c.ShouldBindJSON(&event); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	createdEvent, err := h.investmentUsecase.AddEvent(c.GetString("user_id"), c.Param("id"), &event)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, createdEvent)
+}
+
+func (h *InvestmentHandler) ListEvents(c *gin.Context) {
+	page, err := bindPageRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	events, err := h.investmentUsecase.ListEvents(c.GetString("user_id"), c.Param("id"), page)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, events)
+}
+
+func (h *InvestmentHandler) DeleteEvent(c *gin.Context) {
+	if err := h.investmentUsecase.DeleteEvent(c.GetString("user_id"), c.Param("id"), c.Param("eventId")); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetHoldings handles GET /investments/accounts/:id/holdings?as_of=
+func (h *InvestmentHandler) GetHoldings(c *gin.Context) {
+	asOf, err := parseDateQuery(c, "as_of")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if asOf.IsZero() {
+		asOf = time.Now()
+	} else {
+		// Include everything that happened on the requested day
+		asOf = asOf.Add(24*time.Hour - time.Nanosecond)
+	}
+
+	report, err := h.investmentUsecase.GetHoldings(c.GetString("user_id"), c.Param("id"), asOf)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// GetRealizedGains handles GET /investments/accounts/:id/realized?from=&to=
+func (h *InvestmentHandler) GetRealizedGains(c *gin.Context) {
+	from, err := parseDateQuery(c, "from")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	to, err := parseDateQuery(c, "to")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if to.IsZero() {
+		to = time.Now()
+	} else {
+		to = to.Add(24*time.Hour - time.Nanosecond)
+	}
+
+	report, err := h.investmentUsecase.GetRealizedGains(c.GetString("user_id"), c.Param("id"), from, to)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// ImportPrices accepts a price history csv in the "file" form field.
+// ?symbol= names the security when the file has no symbol column.
+func (h *InvestmentHandler) ImportPrices(c *gin.Context) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+		return
+	}
+	if fileHeader.Size > maxPriceFileSize {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "file too large"})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer file.Close()
+
+	count, err := h.investmentUsecase.ImportPrices(c.Query("symbol"), file)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"imported": count})
+}
+
+func (h *InvestmentHandler) ListPrices(c *gin.Context) {
+	page, err := bindPageRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	from, err := parseDateQuery(c, "from")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	to, err := parseDateQuery(c, "to")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	prices, err := h.investmentUsecase.ListPrices(c.Param("symbol"), from, to, page)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, prices)
+}
+
+// GetPerformance handles GET /reports/investment-performance?from=&to=&account_id=
+func (h *InvestmentHandler) GetPerformance(c *gin.Context) {
+	from, err := parseDateQuery(c, "from")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	to, err := parseDateQuery(c, "to")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if to.IsZero() {
+		now := time.Now().UTC()
+		to = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	}
+
+	report, err := h.investmentUsecase.GetPerformance(c.GetString("user_id"), c.Query("account_id"), from, to)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
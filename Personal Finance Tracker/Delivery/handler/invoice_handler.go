@@ -0,0 +1,192 @@
+package handler
+
+import (
+	"mime"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"personal-finance-tracker/domain/entities"
+	usecase "personal-finance-tracker/UseCase"
+)
+
+type InvoiceHandler struct {
+	invoiceUsecase *usecase.InvoiceUsecase
+}
+
+func NewInvoiceHandler(invoiceUsecase *usecase.InvoiceUsecase) *InvoiceHandler {
+	return &InvoiceHandler{
+		invoiceUsecase: invoiceUsecase,
+	}
+}
+
+func (h *InvoiceHandler) CreateInvoice(c *gin.Context) {
+	var invoice entities.Invoice
+
+	if err := c.ShouldBindJSON(&invoice); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	createdInvoice, err := h.invoiceUsecase.CreateInvoice(c.GetString("user_id"), &invoice)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, createdInvoice)
+}
+
+// ListInvoices handles GET /invoices?status=
+func (h *InvoiceHandler) ListInvoices(c *gin.Context) {
+	page, err := bindPageRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	invoices, err := h.invoiceUsecase.ListInvoices(c.GetString("user_id"), c.Query("status"), page)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, invoices)
+}
+
+func (h *InvoiceHandler) GetInvoice(c *gin.Context) {
+	invoice, err := h.invoiceUsecase.GetInvoice(c.GetString("user_id"), c.Param("id"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, invoice)
+}
+
+func (h *InvoiceHandler) UpdateInvoice(c *gin.Context) {
+	var invoice entities.Invoice
+
+	if err := c.ShouldBindJSON(&invoice); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updatedInvoice, err := h.invoiceUsecase.UpdateInvoice(c.GetString("user_id"), c.Param("id"), &invoice)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, updatedInvoice)
+}
+
+func (h *InvoiceHandler) DeleteInvoice(c *gin.Context) {
+	if err := h.invoiceUsecase.DeleteInvoice(c.GetString("user_id"), c.Param("id")); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *InvoiceHandler) IssueInvoice(c *gin.Context) {
+	invoice, err := h.invoiceUsecase.IssueInvoice(c.GetString("user_id"), c.Param("id"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, invoice)
+}
+
+func (h *InvoiceHandler) SendInvoice(c *gin.Context) {
+	invoice, err := h.invoiceUsecase.SendInvoice(c.GetString("user_id"), c.Param("id"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, invoice)
+}
+
+func (h *InvoiceHandler) VoidInvoice(c *gin.Context) {
+	invoice, err := h.invoiceUsecase.VoidInvoice(c.GetString("user_id"), c.Param("id"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, invoice)
+}
+
+// DownloadPDF handles GET /invoices/:id/pdf
+func (h *InvoiceHandler) DownloadPDF(c *gin.Context) {
+	pdf, invoice, err := h.invoiceUsecase.RenderPDF(c.GetString("user_id"), c.Param("id"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	name := invoice.Number
+	if name == "" {
+		name = "draft-" + invoice.ID.Hex()
+	}
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": "invoice-" + name + ".pdf"}))
+	c.Data(http.StatusOK, "application/pdf", pdf)
+}
+
+func (h *InvoiceHandler) RecordPayment(c *gin.Context) {
+	var payment entities.InvoicePayment
+
+	if err := c.ShouldBindJSON(&payment); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	invoice, err := h.invoiceUsecase.RecordPayment(c.GetString("user_id"), c.Param("id"), payment)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, invoice)
+}
+
+func (h *InvoiceHandler) RemovePayment(c *gin.Context) {
+	invoice, err := h.invoiceUsecase.RemovePayment(c.GetString("user_id"), c.Param("id"), c.Param("paymentId"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, invoice)
+}
+
+// MatchPayment handles POST /invoices/payments/match. An unmatched payment
+// is not an error: the response lists the candidate invoices instead.
+func (h *InvoiceHandler) MatchPayment(c *gin.Context) {
+	var payment entities.IncomingPayment
+
+	if err := c.ShouldBindJSON(&payment); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	match, err := h.invoiceUsecase.MatchPayment(c.GetString("user_id"), payment)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, match)
+}
+
+func (h *InvoiceHandler) GetReceivables(c *gin.Context) {
+	receivables, err := h.invoiceUsecase.GetReceivables(c.GetString("user_id"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, receivables)
+}
@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"personal-finance-tracker/domain/entities"
+)
+
+// bindPageRequest reads the shared ?limit=&cursor=&sort=&fields= query parameters
+func bindPageRequest(c *gin.Context) (entities.PageRequest, error) {
+	page := entities.PageRequest{
+		Cursor: c.Query("cursor"),
+		Sort:   c.Query("sort"),
+	}
+
+	if limit := c.Query("limit"); limit != "" {
+		value, err := strconv.ParseInt(limit, 10, 64)
+		if err != nil || value <= 0 {
+			return page, errors.New("invalid limit")
+		}
+		page.Limit = value
+	}
+
+	if fields := c.Query("fields"); fields != "" {
+		for _, field := range strings.Split(fields, ",") {
+			if field = strings.TrimSpace(field); field != "" {
+				page.Fields = append(page.Fields, field)
+			}
+		}
+	}
+
+	return page, nil
+}
@@ -0,0 +1,163 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"personal-finance-tracker/domain/entities"
+	usecase "personal-finance-tracker/UseCase"
+)
+
+type PayeeHandler struct {
+	payeeUsecase *usecase.PayeeUsecase
+}
+
+func NewPayeeHandler(payeeUsecase *usecase.PayeeUsecase) *PayeeHandler {
+	return &PayeeHandler{
+		payeeUsecase: payeeUsecase,
+	}
+}
+
+type aliasRequest struct {
+	Alias string `json:"alias" binding:"required"`
+}
+
+type mergePayeesRequest struct {
+	SourceIDs []string `json:"source_ids" binding:"required"`
+}
+
+type matchPayeesRequest struct {
+	Descriptions []string `json:"descriptions" binding:"required"`
+}
+
+func (h *PayeeHandler) CreatePayee(c *gin.Context) {
+	var payee entities.Payee
+
+	if err := c.ShouldBindJSON(&payee); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	createdPayee, err := h.payeeUsecase.CreatePayee(c.GetString("user_id"), &payee)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, createdPayee)
+}
+
+func (h *PayeeHandler) ListPayees(c *gin.Context) {
+	page, err := bindPageRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	payees, err := h.payeeUsecase.ListPayees(c.GetString("user_id"), page)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, payees)
+}
+
+func (h *PayeeHandler) GetPayee(c *gin.Context) {
+	payee, err := h.payeeUsecase.GetPayee(c.GetString("user_id"), c.Param("id"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, payee)
+}
+
+func (h *PayeeHandler) UpdatePayee(c *gin.Context) {
+	var payee entities.Payee
+
+	if err := c.ShouldBindJSON(&payee); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updatedPayee, err := h.payeeUsecase.UpdatePayee(c.GetString("user_id"), c.Param("id"), &payee)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, updatedPayee)
+}
+
+func (h *PayeeHandler) DeletePayee(c *gin.Context) {
+	if err := h.payeeUsecase.DeletePayee(c.GetString("user_id"), c.Param("id")); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *PayeeHandler) AddAlias(c *gin.Context) {
+	var req aliasRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	payee, err := h.payeeUsecase.AddAlias(c.GetString("user_id"), c.Param("id"), req.Alias)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, payee)
+}
+
+func (h *PayeeHandler) RemoveAlias(c *gin.Context) {
+	payee, err := h.payeeUsecase.RemoveAlias(c.GetString("user_id"), c.Param("id"), c.Param("alias"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, payee)
+}
+
+// MergePayees handles POST /payees/:id/merge, folding source_ids into :id
+func (h *PayeeHandler) MergePayees(c *gin.Context) {
+	var req mergePayeesRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	payee, err := h.payeeUsecase.MergePayees(c.GetString("user_id"), c.Param("id"), req.SourceIDs)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, payee)
+}
+
+// MatchPayees handles POST /payees/match for a batch of raw descriptions
+func (h *PayeeHandler) MatchPayees(c *gin.Context) {
+	var req matchPayeesRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	matches, err := h.payeeUsecase.MatchDescriptions(c.GetString("user_id"), req.Descriptions)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"matches": matches})
+}
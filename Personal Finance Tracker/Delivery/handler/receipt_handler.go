@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	usecase "personal-finance-tracker/UseCase"
+)
+
+// maxReceiptEmailSize caps uploaded .eml files, attachments included
+const maxReceiptEmailSize = 25 << 20
+
+type ReceiptHandler struct {
+	receiptUsecase *usecase.ReceiptUsecase
+}
+
+func NewReceiptHandler(receiptUsecase *usecase.ReceiptUsecase) *ReceiptHandler {
+	return &ReceiptHandler{
+		receiptUsecase: receiptUsecase,
+	}
+}
+
+// ParseReceipt accepts a forwarded receipt as an .eml file in the "file"
+// form field and returns the draft saved from it
+func (h *ReceiptHandler) ParseReceipt(c *gin.Context) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+		return
+	}
+	if fileHeader.Size > maxReceiptEmailSize {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "file too large"})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer file.Close()
+
+	receipt, err := h.receiptUsecase.ParseReceipt(c.GetString("user_id"), file)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, receipt)
+}
+
+func (h *ReceiptHandler) ListReceipts(c *gin.Context) {
+	page, err := bindPageRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	receipts, err := h.receiptUsecase.ListReceipts(c.GetString("user_id"), page)
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, receipts)
+}
+
+func (h *ReceiptHandler) GetReceipt(c *gin.Context) {
+	receipt, err := h.receiptUsecase.GetReceipt(c.GetString("user_id"), c.Param("id"))
+	if err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, receipt)
+}
+
+func (h *ReceiptHandler) DeleteReceipt(c *gin.Context) {
+	if err := h.receiptUsecase.DeleteReceipt(c.GetString("user_id"), c.Param("id")); err != nil {
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
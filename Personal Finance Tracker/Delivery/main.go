@@ -3,6 +3,7 @@ package main
 import (
 	"log"
 	"os"
+	"time"
 
 	"personal-finance-tracker/Delivery/router"
 	"personal-finance-tracker/Infrastructure/db"
@@ -49,10 +50,49 @@ func main() {
 	// Get database collection
 	database := client.Database(dbName)
 	userCollection := database.Collection("users")
+	rateCollection := database.Collection("exchange_rates")
+	goalCollection := database.Collection("goals")
+	debtCollection := database.Collection("debts")
+	investmentAccountCollection := database.Collection("investment_accounts")
+	investmentEventCollection := database.Collection("investment_events")
+	priceCollection := database.Collection("security_prices")
+	performanceCollection := database.Collection("performance_cache")
+	billCollection := database.Collection("bills")
+	calendarFeedCollection := database.Collection("calendar_feeds")
+	payeeCollection := database.Collection("payees")
+	attachmentCollection := database.Collection("attachments")
+	receiptCollection := database.Collection("receipts")
+	householdCollection := database.Collection("households")
+	invitationCollection := database.Collection("household_invitations")
+	expenseGroupCollection := database.Collection("expense_groups")
+	groupExpenseCollection := database.Collection("group_expenses")
+	invoiceCollection := database.Collection("invoices")
+	invoiceCounterCollection := database.Collection("invoice_counters")
 	log.Printf("📁 Using collection: %s", userCollection.Name())
 
 	// Initialize repositories
 	userRepo := repository.NewUserRepository(userCollection)
+	rateRepo := repository.NewExchangeRateRepository(rateCollection)
+	goalRepo := repository.NewGoalRepository(goalCollection)
+	debtRepo := repository.NewDebtRepository(debtCollection)
+	investmentAccountRepo := repository.NewInvestmentAccountRepository(investmentAccountCollection)
+	investmentEventRepo := repository.NewInvestmentEventRepository(investmentEventCollection)
+	priceRepo := repository.NewPriceRepository(priceCollection)
+	perfCacheRepo := repository.NewPerformanceCacheRepository(performanceCollection)
+	billRepo := repository.NewBillRepository(billCollection)
+	calendarFeedRepo := repository.NewCalendarFeedRepository(calendarFeedCollection)
+	payeeRepo := repository.NewPayeeRepository(payeeCollection)
+	attachmentRepo := repository.NewAttachmentRepository(attachmentCollection)
+	fileStore, err := repository.NewGridFSFileStore(database, "attachment_files")
+	if err != nil {
+		log.Fatalf("❌ GridFS setup failed: %v", err)
+	}
+	receiptRepo := repository.NewReceiptRepository(receiptCollection)
+	householdRepo := repository.NewHouseholdRepository(householdCollection)
+	invitationRepo := repository.NewHouseholdInvitationRepository(invitationCollection)
+	expenseGroupRepo := repository.NewExpenseGroupRepository(expenseGroupCollection)
+	groupExpenseRepo := repository.NewGroupExpenseRepository(groupExpenseCollection)
+	invoiceRepo := repository.NewInvoiceRepository(invoiceCollection, invoiceCounterCollection)
 
 	// Initialize services
 	jwtSecret := os.Getenv("JWT_SECRET")
@@ -60,12 +100,28 @@ func main() {
 		jwtSecret = "default-secret-key"
 	}
 	jwtService := services.NewJWTService(jwtSecret)
+	emailService := services.NewEmailService()
 
 	// Initialize use cases
 	userUsecase := usecase.NewUserUsecase(userRepo)
+	rateUsecase := usecase.NewExchangeRateUsecase(rateRepo, userRepo)
+	attachmentUsecase := usecase.NewAttachmentUsecase(attachmentRepo, fileStore, billRepo, debtRepo, goalRepo, investmentAccountRepo, receiptRepo)
+	householdUsecase := usecase.NewHouseholdUsecase(householdRepo, invitationRepo, userRepo, goalRepo, emailService)
+	goalUsecase := usecase.NewGoalUsecase(goalRepo, userRepo, attachmentUsecase, householdUsecase)
+	debtUsecase := usecase.NewDebtUsecase(debtRepo, attachmentUsecase)
+	investmentUsecase := usecase.NewInvestmentUsecase(investmentAccountRepo, investmentEventRepo, priceRepo, perfCacheRepo, attachmentUsecase)
+	billUsecase := usecase.NewBillUsecase(billRepo, userRepo, emailService, attachmentUsecase)
+	calendarUsecase := usecase.NewCalendarUsecase(calendarFeedRepo, billRepo, goalRepo)
+	payeeUsecase := usecase.NewPayeeUsecase(payeeRepo)
+	receiptUsecase := usecase.NewReceiptUsecase(receiptRepo, payeeRepo, attachmentUsecase)
+	expenseGroupUsecase := usecase.NewExpenseGroupUsecase(expenseGroupRepo, groupExpenseRepo, userRepo)
+	invoiceUsecase := usecase.NewInvoiceUsecase(invoiceRepo, userRepo, emailService)
+
+	// Send bill reminders in the background
+	billUsecase.StartReminderScheduler(time.Hour)
 
 	// Setup router with dependencies
-	router := router.SetupRouter(userUsecase, jwtService)
+	router := router.SetupRouter(userUsecase, rateUsecase, goalUsecase, debtUsecase, investmentUsecase, billUsecase, calendarUsecase, payeeUsecase, attachmentUsecase, receiptUsecase, householdUsecase, expenseGroupUsecase, invoiceUsecase, jwtService)
 
 	// Start server
 	log.Println("🚀 Personal Finance Tracker API running on http://localhost:8080")
@@ -3,12 +3,25 @@ package router
 import (
 	"github.com/gin-gonic/gin"
 	"personal-finance-tracker/Delivery/handler"
+	"personal-finance-tracker/Delivery/middleware"
 	usecase "personal-finance-tracker/UseCase"
 	"personal-finance-tracker/Infrastructure/service"
 )
 
 func SetupRouter(
 	userUsecase *usecase.UserUsecase,
+	rateUsecase *usecase.ExchangeRateUsecase,
+	goalUsecase *usecase.GoalUsecase,
+	debtUsecase *usecase.DebtUsecase,
+	investmentUsecase *usecase.InvestmentUsecase,
+	billUsecase *usecase.BillUsecase,
+	calendarUsecase *usecase.CalendarUsecase,
+	payeeUsecase *usecase.PayeeUsecase,
+	attachmentUsecase *usecase.AttachmentUsecase,
+	receiptUsecase *usecase.ReceiptUsecase,
+	householdUsecase *usecase.HouseholdUsecase,
+	expenseGroupUsecase *usecase.ExpenseGroupUsecase,
+	invoiceUsecase *usecase.InvoiceUsecase,
 	jwtService *services.JWTService,
 ) *gin.Engine {
 
@@ -16,10 +29,136 @@ func SetupRouter(
 
 	// Initialize handlers
 	userHandler := handler.NewUserHandler(userUsecase)
+	rateHandler := handler.NewExchangeRateHandler(rateUsecase)
+	goalHandler := handler.NewGoalHandler(goalUsecase)
+	debtHandler := handler.NewDebtHandler(debtUsecase)
+	investmentHandler := handler.NewInvestmentHandler(investmentUsecase)
+	billHandler := handler.NewBillHandler(billUsecase)
+	calendarHandler := handler.NewCalendarHandler(calendarUsecase)
+	payeeHandler := handler.NewPayeeHandler(payeeUsecase)
+	attachmentHandler := handler.NewAttachmentHandler(attachmentUsecase)
+	receiptHandler := handler.NewReceiptHandler(receiptUsecase)
+	householdHandler := handler.NewHouseholdHandler(householdUsecase)
+	expenseGroupHandler := handler.NewExpenseGroupHandler(expenseGroupUsecase)
+	invoiceHandler := handler.NewInvoiceHandler(invoiceUsecase)
 
 	// Public routes
 	router.POST("/register", userHandler.Register)
 	// router.POST("/login", userHandler.Login) // Uncomment when login is implemented
+	router.GET("/calendar/:file", calendarHandler.GetFeed)
+	router.GET("/households/invitations/accept", householdHandler.PreviewInvitation)
+
+	// Authenticated routes
+	api := router.Group("/")
+	api.Use(middleware.AuthMiddleware(jwtService))
+	{
+		api.GET("/exchange-rates", rateHandler.ListRates)
+		api.GET("/exchange-rates/convert", rateHandler.Convert)
+		api.POST("/goals", goalHandler.CreateGoal)
+		api.GET("/goals", goalHandler.ListGoals)
+		api.GET("/goals/:id", goalHandler.GetGoal)
+		api.PUT("/goals/:id", goalHandler.UpdateGoal)
+		api.DELETE("/goals/:id", goalHandler.DeleteGoal)
+		api.POST("/goals/:id/contributions", goalHandler.AddContribution)
+		api.DELETE("/goals/:id/contributions/:contributionId", goalHandler.RemoveContribution)
+		api.GET("/goals/:id/projection", goalHandler.GetProjection)
+		api.POST("/debts", debtHandler.CreateDebt)
+		api.GET("/debts", debtHandler.ListDebts)
+		api.GET("/debts/payoff", debtHandler.SimulatePayoff)
+		api.GET("/debts/:id", debtHandler.GetDebt)
+		api.PUT("/debts/:id", debtHandler.UpdateDebt)
+		api.DELETE("/debts/:id", debtHandler.DeleteDebt)
+		api.POST("/debts/:id/extra-payments", debtHandler.AddExtraPayment)
+		api.DELETE("/debts/:id/extra-payments/:paymentId", debtHandler.RemoveExtraPayment)
+		api.GET("/debts/:id/amortization", debtHandler.GetAmortization)
+		api.POST("/investments/accounts", investmentHandler.CreateAccount)
+		api.GET("/investments/accounts", investmentHandler.ListAccounts)
+		api.GET("/investments/accounts/:id", investmentHandler.GetAccount)
+		api.PUT("/investments/accounts/:id", investmentHandler.UpdateAccount)
+		api.DELETE("/investments/accounts/:id", investmentHandler.DeleteAccount)
+		api.POST("/investments/accounts/:id/events", investmentHandler.AddEvent)
+		api.GET("/investments/accounts/:id/events", investmentHandler.ListEvents)
+		api.DELETE("/investments/accounts/:id/events/:eventId", investmentHandler.DeleteEvent)
+		api.GET("/investments/accounts/:id/holdings", investmentHandler.GetHoldings)
+		api.GET("/investments/accounts/:id/realized", investmentHandler.GetRealizedGains)
+		api.GET("/prices/:symbol", investmentHandler.ListPrices)
+		api.GET("/reports/investment-performance", investmentHandler.GetPerformance)
+		api.GET("/bills/upcoming", billHandler.GetUpcoming)
+		api.POST("/bills", billHandler.CreateBill)
+		api.GET("/bills", billHandler.ListBills)
+		api.GET("/bills/:id", billHandler.GetBill)
+		api.PUT("/bills/:id", billHandler.UpdateBill)
+		api.DELETE("/bills/:id", billHandler.DeleteBill)
+		api.POST("/bills/:id/payments", billHandler.RecordPayment)
+		api.DELETE("/bills/:id/payments/:paymentId", billHandler.RemovePayment)
+		api.POST("/calendar/token", calendarHandler.CreateFeedToken)
+		api.DELETE("/calendar/token", calendarHandler.RevokeFeedToken)
+		api.POST("/payees/match", payeeHandler.MatchPayees)
+		api.POST("/payees", payeeHandler.CreatePayee)
+		api.GET("/payees", payeeHandler.ListPayees)
+		api.GET("/payees/:id", payeeHandler.GetPayee)
+		api.PUT("/payees/:id", payeeHandler.UpdatePayee)
+		api.DELETE("/payees/:id", payeeHandler.DeletePayee)
+		api.POST("/payees/:id/aliases", payeeHandler.AddAlias)
+		api.DELETE("/payees/:id/aliases/:alias", payeeHandler.RemoveAlias)
+		api.POST("/payees/:id/merge", payeeHandler.MergePayees)
+		api.GET("/attachments/usage", attachmentHandler.GetUsage)
+		api.POST("/attachments", attachmentHandler.Upload)
+		api.GET("/attachments", attachmentHandler.ListAttachments)
+		api.GET("/attachments/:id", attachmentHandler.GetAttachment)
+		api.GET("/attachments/:id/download", attachmentHandler.Download)
+		api.GET("/attachments/:id/thumbnail", attachmentHandler.Thumbnail)
+		api.DELETE("/attachments/:id", attachmentHandler.DeleteAttachment)
+		api.POST("/receipts/parse", receiptHandler.ParseReceipt)
+		api.GET("/receipts", receiptHandler.ListReceipts)
+		api.GET("/receipts/:id", receiptHandler.GetReceipt)
+		api.DELETE("/receipts/:id", receiptHandler.DeleteReceipt)
+		api.POST("/households/invitations/accept", householdHandler.AcceptInvitation)
+		api.POST("/households", householdHandler.CreateHousehold)
+		api.GET("/households", householdHandler.ListHouseholds)
+		api.GET("/households/:id", householdHandler.GetHousehold)
+		api.PUT("/households/:id", householdHandler.UpdateHousehold)
+		api.DELETE("/households/:id", householdHandler.DeleteHousehold)
+		api.POST("/households/:id/invitations", householdHandler.InviteMember)
+		api.GET("/households/:id/invitations", householdHandler.ListInvitations)
+		api.DELETE("/households/:id/invitations/:invitationId", householdHandler.RevokeInvitation)
+		api.PUT("/households/:id/members/:userId", householdHandler.UpdateMemberRole)
+		api.DELETE("/households/:id/members/:userId", householdHandler.RemoveMember)
+		api.POST("/expense-groups", expenseGroupHandler.CreateGroup)
+		api.GET("/expense-groups", expenseGroupHandler.ListGroups)
+		api.GET("/expense-groups/:id", expenseGroupHandler.GetGroup)
+		api.PUT("/expense-groups/:id", expenseGroupHandler.UpdateGroup)
+		api.DELETE("/expense-groups/:id", expenseGroupHandler.DeleteGroup)
+		api.POST("/expense-groups/:id/participants", expenseGroupHandler.AddParticipant)
+		api.DELETE("/expense-groups/:id/participants/:participantId", expenseGroupHandler.RemoveParticipant)
+		api.POST("/expense-groups/:id/expenses", expenseGroupHandler.AddExpense)
+		api.GET("/expense-groups/:id/expenses", expenseGroupHandler.ListExpenses)
+		api.DELETE("/expense-groups/:id/expenses/:expenseId", expenseGroupHandler.DeleteExpense)
+		api.POST("/expense-groups/:id/settlements", expenseGroupHandler.RecordSettlement)
+		api.GET("/expense-groups/:id/balances", expenseGroupHandler.GetBalances)
+		api.GET("/invoices/receivables", invoiceHandler.GetReceivables)
+		api.POST("/invoices/payments/match", invoiceHandler.MatchPayment)
+		api.POST("/invoices", invoiceHandler.CreateInvoice)
+		api.GET("/invoices", invoiceHandler.ListInvoices)
+		api.GET("/invoices/:id", invoiceHandler.GetInvoice)
+		api.PUT("/invoices/:id", invoiceHandler.UpdateInvoice)
+		api.DELETE("/invoices/:id", invoiceHandler.DeleteInvoice)
+		api.POST("/invoices/:id/issue", invoiceHandler.IssueInvoice)
+		api.POST("/invoices/:id/send", invoiceHandler.SendInvoice)
+		api.POST("/invoices/:id/void", invoiceHandler.VoidInvoice)
+		api.GET("/invoices/:id/pdf", invoiceHandler.DownloadPDF)
+		api.POST("/invoices/:id/payments", invoiceHandler.RecordPayment)
+		api.DELETE("/invoices/:id/payments/:paymentId", invoiceHandler.RemovePayment)
+	}
+
+	// Admin routes
+	admin := router.Group("/admin")
+	admin.Use(middleware.AuthMiddleware(jwtService), middleware.RequireRole("admin"))
+	{
+		admin.GET("/users", userHandler.ListUsers)
+		admin.POST("/exchange-rates/import", rateHandler.ImportRates)
+		admin.POST("/prices/import", investmentHandler.ImportPrices)
+	}
 
 	// Health check
 	router.GET("/health", func(c *gin.Context) {
@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"personal-finance-tracker/domain/entities"
+	repoInterface "personal-finance-tracker/domain/interface"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var attachmentListSpec = ListSpec{
+	SortFields: map[string]string{
+		"filename":   "filename",
+		"size":       "size",
+		"created_at": "created_at",
+	},
+	DefaultSort: "-created_at",
+	Fields: map[string]string{
+		"parent_type":  "parent_type",
+		"parent_id":    "parent_id",
+		"filename":     "filename",
+		"content_type": "content_type",
+		"size":         "size",
+		"created_at":   "created_at",
+	},
+}
+
+type AttachmentRepositoryImpl struct {
+	db *mongo.Collection
+}
+
+func NewAttachmentRepository(db *mongo.Collection) repoInterface.AttachmentRepository {
+	return &AttachmentRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *AttachmentRepositoryImpl) CreateAttachment(attachment *entities.Attachment) (*entities.Attachment, error) {
+	result, err := r.db.InsertOne(context.TODO(), attachment)
+	if err != nil {
+		return nil, err
+	}
+	attachment.ID = result.InsertedID.(primitive.ObjectID)
+	return attachment, nil
+}
+
+func (r *AttachmentRepositoryImpl) GetAttachmentByID(id string, userID string) (*entities.Attachment, error) {
+	filter, err := ownerFilter(id, userID, "attachment")
+	if err != nil {
+		return nil, err
+	}
+
+	var attachment entities.Attachment
+	err = r.db.FindOne(context.TODO(), filter).Decode(&attachment)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errors.New("attachment not found")
+		}
+		return nil, err
+	}
+
+	return &attachment, nil
+}
+
+// ListAttachments lists the user's attachments, narrowed to one parent
+// record when parentType and parentID are given
+func (r *AttachmentRepositoryImpl) ListAttachments(userID string, parentType string, parentID string, page entities.PageRequest) (*entities.Page[entities.Attachment], error) {
+	filter, err := userFilter(userID)
+	if err != nil {
+		return nil, err
+	}
+	if parentType != "" {
+		filter["parent_type"] = parentType
+	}
+	if parentID != "" {
+		objectID, err := primitive.ObjectIDFromHex(parentID)
+		if err != nil {
+			return nil, errors.New("invalid parent id")
+		}
+		filter["parent_id"] = objectID
+	}
+	return paginate[entities.Attachment](r.db, filter, page, attachmentListSpec)
+}
+
+func (r *AttachmentRepositoryImpl) DeleteAttachment(id string, userID string) error {
+	filter, err := ownerFilter(id, userID, "attachment")
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.DeleteOne(context.TODO(), filter)
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return errors.New("attachment not found")
+	}
+
+	return nil
+}
+
+func (r *AttachmentRepositoryImpl) DeleteAttachmentsByParent(userID string, parentType string, parentID string) ([]entities.Attachment, error) {
+	filter, err := userFilter(userID)
+	if err != nil {
+		return nil, err
+	}
+	parentObjectID, err := primitive.ObjectIDFromHex(parentID)
+	if err != nil {
+		return nil, errors.New("invalid parent id")
+	}
+	filter["parent_type"] = parentType
+	filter["parent_id"] = parentObjectID
+
+	cursor, err := r.db.Find(context.TODO(), filter)
+	if err != nil {
+		return nil, err
+	}
+	var attachments []entities.Attachment
+	if err := cursor.All(context.TODO(), &attachments); err != nil {
+		return nil, err
+	}
+	if len(attachments) == 0 {
+		return attachments, nil
+	}
+
+	// Only delete what was read, so the files of anything uploaded in
+	// between aren't left without a record
+	ids := make([]primitive.ObjectID, 0, len(attachments))
+	for _, attachment := range attachments {
+		ids = append(ids, attachment.ID)
+	}
+	filter["_id"] = bson.M{"$in": ids}
+	if _, err := r.db.DeleteMany(context.TODO(), filter); err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
+
+func (r *AttachmentRepositoryImpl) TotalSize(userID string) (int64, error) {
+	filter, err := userFilter(userID)
+	if err != nil {
+		return 0, err
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$group", Value: bson.M{"_id": nil, "total": bson.M{"$sum": bson.M{
+			"$add": bson.A{"$size", bson.M{"$ifNull": bson.A{"$thumbnail_size", 0}}},
+		}}}}},
+	}
+	cursor, err := r.db.Aggregate(context.TODO(), pipeline)
+	if err != nil {
+		return 0, err
+	}
+	var results []struct {
+		Total int64 `bson:"total"`
+	}
+	if err := cursor.All(context.TODO(), &results); err != nil {
+		return 0, err
+	}
+	if len(results) == 0 {
+		return 0, nil
+	}
+
+	return results[0].Total, nil
+}
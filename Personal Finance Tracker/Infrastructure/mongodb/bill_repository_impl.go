@@ -0,0 +1,231 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"personal-finance-tracker/domain/entities"
+	repoInterface "personal-finance-tracker/domain/interface"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var billListSpec = ListSpec{
+	SortFields: map[string]string{
+		"payee":      "payee",
+		"due_day":    "due_day",
+		"created_at": "created_at",
+	},
+	DefaultSort: "due_day",
+	Fields: map[string]string{
+		"payee":                "payee",
+		"category":             "category",
+		"amount_min":           "amount_min",
+		"amount_max":           "amount_max",
+		"currency":             "currency",
+		"due_day":              "due_day",
+		"frequency":            "frequency",
+		"start_date":           "start_date",
+		"autopay":              "autopay",
+		"reminder_days_before": "reminder_days_before",
+		"active":               "active",
+		"notes":                "notes",
+		"payments":             "payments",
+		"created_at":           "created_at",
+		"updated_at":           "updated_at",
+	},
+}
+
+type BillRepositoryImpl struct {
+	db *mongo.Collection
+}
+
+func NewBillRepository(db *mongo.Collection) repoInterface.BillRepository {
+	return &BillRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *BillRepositoryImpl) CreateBill(bill *entities.Bill) (*entities.Bill, error) {
+	result, err := r.db.InsertOne(context.TODO(), bill)
+	if err != nil {
+		return nil, err
+	}
+	bill.ID = result.InsertedID.(primitive.ObjectID)
+	return bill, nil
+}
+
+func (r *BillRepositoryImpl) GetBillByID(id string, userID string) (*entities.Bill, error) {
+	filter, err := ownerFilter(id, userID, "bill")
+	if err != nil {
+		return nil, err
+	}
+
+	var bill entities.Bill
+	err = r.db.FindOne(context.TODO(), filter).Decode(&bill)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errors.New("bill not found")
+		}
+		return nil, err
+	}
+
+	return &bill, nil
+}
+
+func (r *BillRepositoryImpl) ListBills(userID string, page entities.PageRequest) (*entities.Page[entities.Bill], error) {
+	filter, err := userFilter(userID)
+	if err != nil {
+		return nil, err
+	}
+	return paginate[entities.Bill](r.db, filter, page, billListSpec)
+}
+
+// GetActiveBills returns the user's active bills
+func (r *BillRepositoryImpl) GetActiveBills(userID string) ([]entities.Bill, error) {
+	filter, err := userFilter(userID)
+	if err != nil {
+		return nil, err
+	}
+	filter["active"] = true
+	return r.find(filter)
+}
+
+// GetAllActiveBills returns active bills of every user, for the reminder job
+func (r *BillRepositoryImpl) GetAllActiveBills() ([]entities.Bill, error) {
+	return r.find(bson.M{"active": true})
+}
+
+func (r *BillRepositoryImpl) find(filter bson.M) ([]entities.Bill, error) {
+	cursor, err := r.db.Find(context.TODO(), filter)
+	if err != nil {
+		return nil, err
+	}
+	bills := []entities.Bill{}
+	if err := cursor.All(context.TODO(), &bills); err != nil {
+		return nil, err
+	}
+
+	return bills, nil
+}
+
+// UpdateBill saves the editable fields; payments are changed through
+// AddPayment and RemovePayment only
+func (r *BillRepositoryImpl) UpdateBill(bill *entities.Bill) (*entities.Bill, error) {
+	filter := bson.M{"_id": bill.ID, "user_id": bill.UserID}
+	update := bson.M{
+		"$set": bson.M{
+			"payee":                bill.Payee,
+			"category":             bill.Category,
+			"amount_min":           bill.AmountMin,
+			"amount_max":           bill.AmountMax,
+			"currency":             bill.Currency,
+			"due_day":              bill.DueDay,
+			"frequency":            bill.Frequency,
+			"start_date":           bill.StartDate,
+			"autopay":              bill.Autopay,
+			"reminder_days_before": bill.ReminderDaysBefore,
+			"active":               bill.Active,
+			"notes":                bill.Notes,
+			"last_reminded_due":    bill.LastRemindedDue,
+			"updated_at":           bill.UpdatedAt,
+		},
+	}
+
+	return r.findOneAndUpdate(filter, update)
+}
+
+func (r *BillRepositoryImpl) DeleteBill(id string, userID string) error {
+	filter, err := ownerFilter(id, userID, "bill")
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.DeleteOne(context.TODO(), filter)
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return errors.New("bill not found")
+	}
+
+	return nil
+}
+
+func (r *BillRepositoryImpl) AddPayment(billID string, userID string, payment entities.BillPayment) (*entities.Bill, error) {
+	filter, err := ownerFilter(billID, userID, "bill")
+	if err != nil {
+		return nil, err
+	}
+	update := bson.M{
+		"$push": bson.M{"payments": payment},
+		"$set":  bson.M{"updated_at": time.Now()},
+	}
+
+	return r.findOneAndUpdate(filter, update)
+}
+
+func (r *BillRepositoryImpl) RemovePayment(billID string, userID string, paymentID string) (*entities.Bill, error) {
+	filter, err := ownerFilter(billID, userID, "bill")
+	if err != nil {
+		return nil, err
+	}
+	objectID, err := primitive.ObjectIDFromHex(paymentID)
+	if err != nil {
+		return nil, errors.New("invalid payment id")
+	}
+	filter["payments._id"] = objectID
+	update := bson.M{
+		"$pull": bson.M{"payments": bson.M{"_id": objectID}},
+		"$set":  bson.M{"updated_at": time.Now()},
+	}
+
+	bill, err := r.findOneAndUpdate(filter, update)
+	if err != nil {
+		if err.Error() == "bill not found" {
+			return nil, errors.New("payment not found")
+		}
+		return nil, err
+	}
+	return bill, nil
+}
+
+// SetLastReminded records the due date a reminder was sent for, so the
+// reminder job doesn't send it twice
+func (r *BillRepositoryImpl) SetLastReminded(billID string, dueDate time.Time) error {
+	objectID, err := primitive.ObjectIDFromHex(billID)
+	if err != nil {
+		return errors.New("invalid bill id")
+	}
+
+	result, err := r.db.UpdateOne(context.TODO(), bson.M{"_id": objectID}, bson.M{"$set": bson.M{"last_reminded_due": dueDate}})
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return errors.New("bill not found")
+	}
+
+	return nil
+}
+
+func (r *BillRepositoryImpl) findOneAndUpdate(filter bson.M, update bson.M) (*entities.Bill, error) {
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var bill entities.Bill
+	err := r.db.FindOneAndUpdate(context.TODO(), filter, update, opts).Decode(&bill)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errors.New("bill not found")
+		}
+		return nil, err
+	}
+
+	return &bill, nil
+}
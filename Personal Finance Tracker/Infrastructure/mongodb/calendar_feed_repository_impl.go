@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"personal-finance-tracker/domain/entities"
+	repoInterface "personal-finance-tracker/domain/interface"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type CalendarFeedRepositoryImpl struct {
+	db *mongo.Collection
+}
+
+func NewCalendarFeedRepository(db *mongo.Collection) repoInterface.CalendarFeedRepository {
+	return &CalendarFeedRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *CalendarFeedRepositoryImpl) ReplaceFeed(feed *entities.CalendarFeed) (*entities.CalendarFeed, error) {
+	if _, err := r.db.DeleteMany(context.TODO(), bson.M{"user_id": feed.UserID}); err != nil {
+		return nil, err
+	}
+
+	result, err := r.db.InsertOne(context.TODO(), feed)
+	if err != nil {
+		return nil, err
+	}
+	feed.ID = result.InsertedID.(primitive.ObjectID)
+	return feed, nil
+}
+
+func (r *CalendarFeedRepositoryImpl) GetFeedByTokenHash(tokenHash string) (*entities.CalendarFeed, error) {
+	var feed entities.CalendarFeed
+	err := r.db.FindOne(context.TODO(), bson.M{"token_hash": tokenHash}).Decode(&feed)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errors.New("calendar feed not found")
+		}
+		return nil, err
+	}
+
+	return &feed, nil
+}
+
+func (r *CalendarFeedRepositoryImpl) DeleteFeed(userID string) error {
+	filter, err := userFilter(userID)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.DeleteMany(context.TODO(), filter)
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return errors.New("calendar feed not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,202 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"personal-finance-tracker/domain/entities"
+	repoInterface "personal-finance-tracker/domain/interface"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var debtListSpec = ListSpec{
+	SortFields: map[string]string{
+		"name":            "name",
+		"apr":             "apr",
+		"current_balance": "current_balance",
+		"created_at":      "created_at",
+	},
+	DefaultSort: "-apr",
+	Fields: map[string]string{
+		"name":              "name",
+		"type":              "type",
+		"lender":            "lender",
+		"currency":          "currency",
+		"principal":         "principal",
+		"current_balance":   "current_balance",
+		"apr":               "apr",
+		"compounding":       "compounding",
+		"payment_frequency": "payment_frequency",
+		"payment_amount":    "payment_amount",
+		"term_months":       "term_months",
+		"start_date":        "start_date",
+		"extra_payments":    "extra_payments",
+		"created_at":        "created_at",
+		"updated_at":        "updated_at",
+	},
+}
+
+type DebtRepositoryImpl struct {
+	db *mongo.Collection
+}
+
+func NewDebtRepository(db *mongo.Collection) repoInterface.DebtRepository {
+	return &DebtRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *DebtRepositoryImpl) CreateDebt(debt *entities.Debt) (*entities.Debt, error) {
+	result, err := r.db.InsertOne(context.TODO(), debt)
+	if err != nil {
+		return nil, err
+	}
+	debt.ID = result.InsertedID.(primitive.ObjectID)
+	return debt, nil
+}
+
+func (r *DebtRepositoryImpl) GetDebtByID(id string, userID string) (*entities.Debt, error) {
+	filter, err := ownerFilter(id, userID, "debt")
+	if err != nil {
+		return nil, err
+	}
+
+	var debt entities.Debt
+	err = r.db.FindOne(context.TODO(), filter).Decode(&debt)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errors.New("debt not found")
+		}
+		return nil, err
+	}
+
+	return &debt, nil
+}
+
+func (r *DebtRepositoryImpl) ListDebts(userID string, page entities.PageRequest) (*entities.Page[entities.Debt], error) {
+	filter, err := userFilter(userID)
+	if err != nil {
+		return nil, err
+	}
+	return paginate[entities.Debt](r.db, filter, page, debtListSpec)
+}
+
+// GetAllDebts returns every debt of the user, for payoff simulations
+func (r *DebtRepositoryImpl) GetAllDebts(userID string) ([]entities.Debt, error) {
+	filter, err := userFilter(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := r.db.Find(context.TODO(), filter)
+	if err != nil {
+		return nil, err
+	}
+	debts := []entities.Debt{}
+	if err := cursor.All(context.TODO(), &debts); err != nil {
+		return nil, err
+	}
+
+	return debts, nil
+}
+
+// UpdateDebt saves the editable fields; extra payments are changed through
+// AddExtraPayment and RemoveExtraPayment only
+func (r *DebtRepositoryImpl) UpdateDebt(debt *entities.Debt) (*entities.Debt, error) {
+	filter := bson.M{"_id": debt.ID, "user_id": debt.UserID}
+	update := bson.M{
+		"$set": bson.M{
+			"name":              debt.Name,
+			"type":              debt.Type,
+			"lender":            debt.Lender,
+			"currency":          debt.Currency,
+			"principal":         debt.Principal,
+			"current_balance":   debt.CurrentBalance,
+			"apr":               debt.APR,
+			"compounding":       debt.Compounding,
+			"payment_frequency": debt.PaymentFrequency,
+			"payment_amount":    debt.PaymentAmount,
+			"term_months":       debt.TermMonths,
+			"start_date":        debt.StartDate,
+			"updated_at":        debt.UpdatedAt,
+		},
+	}
+
+	return r.findOneAndUpdate(filter, update)
+}
+
+func (r *DebtRepositoryImpl) DeleteDebt(id string, userID string) error {
+	filter, err := ownerFilter(id, userID, "debt")
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.DeleteOne(context.TODO(), filter)
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return errors.New("debt not found")
+	}
+
+	return nil
+}
+
+func (r *DebtRepositoryImpl) AddExtraPayment(debtID string, userID string, payment entities.ExtraPayment) (*entities.Debt, error) {
+	filter, err := ownerFilter(debtID, userID, "debt")
+	if err != nil {
+		return nil, err
+	}
+	update := bson.M{
+		"$push": bson.M{"extra_payments": payment},
+		"$set":  bson.M{"updated_at": time.Now()},
+	}
+
+	return r.findOneAndUpdate(filter, update)
+}
+
+func (r *DebtRepositoryImpl) RemoveExtraPayment(debtID string, userID string, paymentID string) (*entities.Debt, error) {
+	filter, err := ownerFilter(debtID, userID, "debt")
+	if err != nil {
+		return nil, err
+	}
+	objectID, err := primitive.ObjectIDFromHex(paymentID)
+	if err != nil {
+		return nil, errors.New("invalid extra payment id")
+	}
+	filter["extra_payments._id"] = objectID
+	update := bson.M{
+		"$pull": bson.M{"extra_payments": bson.M{"_id": objectID}},
+		"$set":  bson.M{"updated_at": time.Now()},
+	}
+
+	debt, err := r.findOneAndUpdate(filter, update)
+	if err != nil {
+		if err.Error() == "debt not found" {
+			return nil, errors.New("extra payment not found")
+		}
+		return nil, err
+	}
+	return debt, nil
+}
+
+func (r *DebtRepositoryImpl) findOneAndUpdate(filter bson.M, update bson.M) (*entities.Debt, error) {
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var debt entities.Debt
+	err := r.db.FindOneAndUpdate(context.TODO(), filter, update, opts).Decode(&debt)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errors.New("debt not found")
+		}
+		return nil, err
+	}
+
+	return &debt, nil
+}
@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"personal-finance-tracker/domain/entities"
+	repoInterface "personal-finance-tracker/domain/interface"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var exchangeRateListSpec = ListSpec{
+	SortFields: map[string]string{
+		"date": "date",
+	},
+	DefaultSort: "-date",
+	Fields: map[string]string{
+		"base":  "base",
+		"quote": "quote",
+		"date":  "date",
+		"rate":  "rate",
+	},
+}
+
+type ExchangeRateRepositoryImpl struct {
+	db *mongo.Collection
+}
+
+func NewExchangeRateRepository(db *mongo.Collection) repoInterface.ExchangeRateRepository {
+	return &ExchangeRateRepositoryImpl{
+		db: db,
+	}
+}
+
+// UpsertRates stores rates keyed by base, quote and date, so re-importing
+// the same file replaces earlier values instead of duplicating them. The
+// count includes rates that were already stored unchanged.
+func (r *ExchangeRateRepositoryImpl) UpsertRates(rates []entities.ExchangeRate) (int64, error) {
+	if len(rates) == 0 {
+		return 0, nil
+	}
+
+	models := make([]mongo.WriteModel, 0, len(rates))
+	now := time.Now()
+	for _, rate := range rates {
+		filter := bson.M{"base": rate.Base, "quote": rate.Quote, "date": rate.Date}
+		update := bson.M{
+			"$set":         bson.M{"rate": rate.Rate, "source": rate.Source},
+			"$setOnInsert": bson.M{"created_at": now},
+		}
+		models = append(models, mongo.NewUpdateOneModel().SetFilter(filter).SetUpdate(update).SetUpsert(true))
+	}
+
+	result, err := r.db.BulkWrite(context.TODO(), models, options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		return 0, err
+	}
+	return result.UpsertedCount + result.MatchedCount, nil
+}
+
+// GetRateOnOrBefore returns the latest rate published on or before date.
+// Rates older than maxAge are ignored so stale data isn't used silently.
+func (r *ExchangeRateRepositoryImpl) GetRateOnOrBefore(base, quote string, date time.Time, maxAge time.Duration) (*entities.ExchangeRate, error) {
+	filter := bson.M{
+		"base":  base,
+		"quote": quote,
+		"date":  bson.M{"$lte": date, "$gte": date.Add(-maxAge)},
+	}
+	opts := options.FindOne().SetSort(bson.D{{Key: "date", Value: -1}})
+
+	var rate entities.ExchangeRate
+	err := r.db.FindOne(context.TODO(), filter, opts).Decode(&rate)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errors.New("exchange rate not found")
+		}
+		return nil, err
+	}
+
+	return &rate, nil
+}
+
+func (r *ExchangeRateRepositoryImpl) ListRates(base, quote string, from, to time.Time, page entities.PageRequest) (*entities.Page[entities.ExchangeRate], error) {
+	filter := bson.M{"base": base}
+	if quote != "" {
+		filter["quote"] = quote
+	}
+	dateFilter := bson.M{}
+	if !from.IsZero() {
+		dateFilter["$gte"] = from
+	}
+	if !to.IsZero() {
+		dateFilter["$lte"] = to
+	}
+	if len(dateFilter) > 0 {
+		filter["date"] = dateFilter
+	}
+
+	return paginate[entities.ExchangeRate](r.db, filter, page, exchangeRateListSpec)
+}
@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"personal-finance-tracker/domain/entities"
+	repoInterface "personal-finance-tracker/domain/interface"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var expenseGroupListSpec = ListSpec{
+	SortFields: map[string]string{
+		"name":       "name",
+		"created_at": "created_at",
+	},
+	DefaultSort: "-created_at",
+	Fields: map[string]string{
+		"name":         "name",
+		"currency":     "currency",
+		"participants": "participants",
+		"created_by":   "created_by",
+		"created_at":   "created_at",
+		"updated_at":   "updated_at",
+	},
+}
+
+// Groups are shared, so access is checked against the participants by the
+// use case rather than by a user_id filter here
+type ExpenseGroupRepositoryImpl struct {
+	db *mongo.Collection
+}
+
+func NewExpenseGroupRepository(db *mongo.Collection) repoInterface.ExpenseGroupRepository {
+	return &ExpenseGroupRepositoryImpl{
+		db: db,
+	}
+}
+
+func expenseGroupFilter(id string) (bson.M, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, errors.New("invalid group id")
+	}
+	return bson.M{"_id": objectID}, nil
+}
+
+func (r *ExpenseGroupRepositoryImpl) CreateGroup(group *entities.ExpenseGroup) (*entities.ExpenseGroup, error) {
+	result, err := r.db.InsertOne(context.TODO(), group)
+	if err != nil {
+		return nil, err
+	}
+	group.ID = result.InsertedID.(primitive.ObjectID)
+	return group, nil
+}
+
+func (r *ExpenseGroupRepositoryImpl) GetGroupByID(id string) (*entities.ExpenseGroup, error) {
+	filter, err := expenseGroupFilter(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var group entities.ExpenseGroup
+	err = r.db.FindOne(context.TODO(), filter).Decode(&group)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errors.New("group not found")
+		}
+		return nil, err
+	}
+
+	return &group, nil
+}
+
+// ListGroups lists the groups the user takes part in
+func (r *ExpenseGroupRepositoryImpl) ListGroups(userID string, page entities.PageRequest) (*entities.Page[entities.ExpenseGroup], error) {
+	memberID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, errors.New("invalid user id")
+	}
+	return paginate[entities.ExpenseGroup](r.db, bson.M{"participants.user_id": memberID}, page, expenseGroupListSpec)
+}
+
+func (r *ExpenseGroupRepositoryImpl) UpdateGroup(group *entities.ExpenseGroup) (*entities.ExpenseGroup, error) {
+	filter := bson.M{"_id": group.ID}
+	update := bson.M{
+		"$set": bson.M{
+			"name":       group.Name,
+			"currency":   group.Currency,
+			"updated_at": group.UpdatedAt,
+		},
+	}
+
+	return r.findOneAndUpdate(filter, update)
+}
+
+func (r *ExpenseGroupRepositoryImpl) DeleteGroup(id string) error {
+	filter, err := expenseGroupFilter(id)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.DeleteOne(context.TODO(), filter)
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return errors.New("group not found")
+	}
+
+	return nil
+}
+
+func (r *ExpenseGroupRepositoryImpl) AddParticipant(groupID string, participant entities.Participant) (*entities.ExpenseGroup, error) {
+	filter, err := expenseGroupFilter(groupID)
+	if err != nil {
+		return nil, err
+	}
+	update := bson.M{
+		"$push": bson.M{"participants": participant},
+		"$set":  bson.M{"updated_at": time.Now()},
+	}
+
+	return r.findOneAndUpdate(filter, update)
+}
+
+func (r *ExpenseGroupRepositoryImpl) RemoveParticipant(groupID string, participantID string) (*entities.ExpenseGroup, error) {
+	filter, err := expenseGroupFilter(groupID)
+	if err != nil {
+		return nil, err
+	}
+	objectID, err := primitive.ObjectIDFromHex(participantID)
+	if err != nil {
+		return nil, errors.New("invalid participant id")
+	}
+	filter["participants._id"] = objectID
+	update := bson.M{
+		"$pull": bson.M{"participants": bson.M{"_id": objectID}},
+		"$set":  bson.M{"updated_at": time.Now()},
+	}
+
+	group, err := r.findOneAndUpdate(filter, update)
+	if err != nil {
+		if err.Error() == "group not found" {
+			return nil, errors.New("participant not found")
+		}
+		return nil, err
+	}
+	return group, nil
+}
+
+func (r *ExpenseGroupRepositoryImpl) findOneAndUpdate(filter bson.M, update bson.M) (*entities.ExpenseGroup, error) {
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var group entities.ExpenseGroup
+	err := r.db.FindOneAndUpdate(context.TODO(), filter, update, opts).Decode(&group)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errors.New("group not found")
+		}
+		return nil, err
+	}
+
+	return &group, nil
+}
@@ -0,0 +1,242 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"personal-finance-tracker/domain/entities"
+	repoInterface "personal-finance-tracker/domain/interface"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var goalListSpec = ListSpec{
+	SortFields: map[string]string{
+		"name":       "name",
+		"priority":   "priority",
+		"created_at": "created_at",
+	},
+	DefaultSort: "priority",
+	Fields: map[string]string{
+		"name":          "name",
+		"household_id":  "household_id",
+		"target_amount": "target_amount",
+		"currency":      "currency",
+		"target_date":   "target_date",
+		"priority":      "priority",
+		"notes":         "notes",
+		"contributions": "contributions",
+		"created_at":    "created_at",
+		"updated_at":    "updated_at",
+	},
+}
+
+type GoalRepositoryImpl struct {
+	db *mongo.Collection
+}
+
+func NewGoalRepository(db *mongo.Collection) repoInterface.GoalRepository {
+	return &GoalRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *GoalRepositoryImpl) CreateGoal(goal *entities.Goal) (*entities.Goal, error) {
+	result, err := r.db.InsertOne(context.TODO(), goal)
+	if err != nil {
+		return nil, err
+	}
+	goal.ID = result.InsertedID.(primitive.ObjectID)
+	return goal, nil
+}
+
+func (r *GoalRepositoryImpl) GetGoalByID(id string, userID string) (*entities.Goal, error) {
+	filter, err := ownerFilter(id, userID, "goal")
+	if err != nil {
+		return nil, err
+	}
+
+	var goal entities.Goal
+	err = r.db.FindOne(context.TODO(), filter).Decode(&goal)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errors.New("goal not found")
+		}
+		return nil, err
+	}
+
+	return &goal, nil
+}
+
+func (r *GoalRepositoryImpl) FindGoalByID(id string) (*entities.Goal, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, errors.New("invalid goal id")
+	}
+
+	var goal entities.Goal
+	err = r.db.FindOne(context.TODO(), bson.M{"_id": objectID}).Decode(&goal)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errors.New("goal not found")
+		}
+		return nil, err
+	}
+
+	return &goal, nil
+}
+
+func (r *GoalRepositoryImpl) ListGoals(userID string, page entities.PageRequest) (*entities.Page[entities.Goal], error) {
+	filter, err := userFilter(userID)
+	if err != nil {
+		return nil, err
+	}
+	return paginate[entities.Goal](r.db, filter, page, goalListSpec)
+}
+
+// ListHouseholdGoals lists the goals shared with a household, whoever owns them
+func (r *GoalRepositoryImpl) ListHouseholdGoals(householdID string, page entities.PageRequest) (*entities.Page[entities.Goal], error) {
+	objectID, err := primitive.ObjectIDFromHex(householdID)
+	if err != nil {
+		return nil, errors.New("invalid household id")
+	}
+	return paginate[entities.Goal](r.db, bson.M{"household_id": objectID}, page, goalListSpec)
+}
+
+// GetGoalsWithDeadline returns every goal of the user that has a target date
+func (r *GoalRepositoryImpl) GetGoalsWithDeadline(userID string) ([]entities.Goal, error) {
+	filter, err := userFilter(userID)
+	if err != nil {
+		return nil, err
+	}
+	filter["target_date"] = bson.M{"$ne": nil}
+
+	cursor, err := r.db.Find(context.TODO(), filter)
+	if err != nil {
+		return nil, err
+	}
+	goals := []entities.Goal{}
+	if err := cursor.All(context.TODO(), &goals); err != nil {
+		return nil, err
+	}
+
+	return goals, nil
+}
+
+// UpdateGoal saves the editable fields; contributions are changed through
+// AddContribution and RemoveContribution only
+func (r *GoalRepositoryImpl) UpdateGoal(goal *entities.Goal) (*entities.Goal, error) {
+	filter := bson.M{"_id": goal.ID, "user_id": goal.UserID}
+	update := bson.M{
+		"$set": bson.M{
+			"name":          goal.Name,
+			"household_id":  goal.HouseholdID,
+			"target_amount": goal.TargetAmount,
+			"currency":      goal.Currency,
+			"target_date":   goal.TargetDate,
+			"priority":      goal.Priority,
+			"notes":         goal.Notes,
+			"updated_at":    goal.UpdatedAt,
+		},
+	}
+
+	return r.findOneAndUpdate(filter, update)
+}
+
+func (r *GoalRepositoryImpl) DeleteGoal(id string, userID string) error {
+	filter, err := ownerFilter(id, userID, "goal")
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.DeleteOne(context.TODO(), filter)
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return errors.New("goal not found")
+	}
+
+	return nil
+}
+
+func (r *GoalRepositoryImpl) UnshareGoals(householdID string, userID string) error {
+	objectID, err := primitive.ObjectIDFromHex(householdID)
+	if err != nil {
+		return errors.New("invalid household id")
+	}
+	filter := bson.M{"household_id": objectID}
+	if userID != "" {
+		ownerID, err := primitive.ObjectIDFromHex(userID)
+		if err != nil {
+			return errors.New("invalid user id")
+		}
+		filter["user_id"] = ownerID
+	}
+
+	_, err = r.db.UpdateMany(context.TODO(), filter, bson.M{"$unset": bson.M{"household_id": ""}})
+	return err
+}
+
+// AddContribution only takes a withdrawal while enough is saved, so
+// concurrent withdrawals cannot overdraw the goal
+func (r *GoalRepositoryImpl) AddContribution(goalID string, userID string, contribution entities.Contribution) (*entities.Goal, error) {
+	filter, err := ownerFilter(goalID, userID, "goal")
+	if err != nil {
+		return nil, err
+	}
+	if contribution.Amount < 0 {
+		filter["$expr"] = savedAtLeast(-contribution.Amount)
+	}
+	update := bson.M{
+		"$push": bson.M{"contributions": contribution},
+		"$set":  bson.M{"updated_at": time.Now()},
+	}
+
+	return r.findOneAndUpdate(filter, update)
+}
+
+// RemoveContribution only removes a deposit while what is saved without it
+// stays at or above zero
+func (r *GoalRepositoryImpl) RemoveContribution(goalID string, userID string, contribution entities.Contribution) (*entities.Goal, error) {
+	filter, err := ownerFilter(goalID, userID, "goal")
+	if err != nil {
+		return nil, err
+	}
+	filter["contributions._id"] = contribution.ID
+	if contribution.Amount > 0 {
+		filter["$expr"] = savedAtLeast(contribution.Amount)
+	}
+	update := bson.M{
+		"$pull": bson.M{"contributions": bson.M{"_id": contribution.ID}},
+		"$set":  bson.M{"updated_at": time.Now()},
+	}
+
+	return r.findOneAndUpdate(filter, update)
+}
+
+// savedAtLeast matches goals whose contributions add up to at least amount.
+// Amounts are in whole cents; the half cent absorbs floating point drift.
+func savedAtLeast(amount float64) bson.M {
+	return bson.M{"$gte": bson.A{bson.M{"$sum": "$contributions.amount"}, amount - 0.005}}
+}
+
+func (r *GoalRepositoryImpl) findOneAndUpdate(filter bson.M, update bson.M) (*entities.Goal, error) {
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var goal entities.Goal
+	err := r.db.FindOneAndUpdate(context.TODO(), filter, update, opts).Decode(&goal)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errors.New("goal not found")
+		}
+		return nil, err
+	}
+
+	return &goal, nil
+}
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"errors"
+	"io"
+
+	repoInterface "personal-finance-tracker/domain/interface"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type GridFSFileStore struct {
+	bucket *gridfs.Bucket
+}
+
+// NewGridFSFileStore stores files in the named GridFS bucket of the database
+func NewGridFSFileStore(db *mongo.Database, bucketName string) (repoInterface.FileStore, error) {
+	bucket, err := gridfs.NewBucket(db, options.GridFSBucket().SetName(bucketName))
+	if err != nil {
+		return nil, err
+	}
+	return &GridFSFileStore{
+		bucket: bucket,
+	}, nil
+}
+
+func (s *GridFSFileStore) Upload(filename string, contentType string, data io.Reader) (primitive.ObjectID, error) {
+	opts := options.GridFSUpload().SetMetadata(bson.M{"content_type": contentType})
+	return s.bucket.UploadFromStream(filename, data, opts)
+}
+
+func (s *GridFSFileStore) Open(id primitive.ObjectID) (io.ReadCloser, error) {
+	stream, err := s.bucket.OpenDownloadStream(id)
+	if err != nil {
+		if err == gridfs.ErrFileNotFound {
+			return nil, errors.New("file not found")
+		}
+		return nil, err
+	}
+	return stream, nil
+}
+
+func (s *GridFSFileStore) Delete(id primitive.ObjectID) error {
+	err := s.bucket.Delete(id)
+	if err != nil && err != gridfs.ErrFileNotFound {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"personal-finance-tracker/domain/entities"
+	repoInterface "personal-finance-tracker/domain/interface"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var groupExpenseListSpec = ListSpec{
+	SortFields: map[string]string{
+		"date":       "date",
+		"amount":     "amount",
+		"created_at": "created_at",
+	},
+	DefaultSort: "-date",
+	Fields: map[string]string{
+		"kind":         "kind",
+		"description":  "description",
+		"amount":       "amount",
+		"date":         "date",
+		"paid_by":      "paid_by",
+		"split_method": "split_method",
+		"splits":       "splits",
+		"created_by":   "created_by",
+		"created_at":   "created_at",
+	},
+}
+
+type GroupExpenseRepositoryImpl struct {
+	db *mongo.Collection
+}
+
+func NewGroupExpenseRepository(db *mongo.Collection) repoInterface.GroupExpenseRepository {
+	return &GroupExpenseRepositoryImpl{
+		db: db,
+	}
+}
+
+func groupExpensesFilter(groupID string) (bson.M, error) {
+	objectID, err := primitive.ObjectIDFromHex(groupID)
+	if err != nil {
+		return nil, errors.New("invalid group id")
+	}
+	return bson.M{"group_id": objectID}, nil
+}
+
+func (r *GroupExpenseRepositoryImpl) CreateExpense(expense *entities.GroupExpense) (*entities.GroupExpense, error) {
+	result, err := r.db.InsertOne(context.TODO(), expense)
+	if err != nil {
+		return nil, err
+	}
+	expense.ID = result.InsertedID.(primitive.ObjectID)
+	return expense, nil
+}
+
+func (r *GroupExpenseRepositoryImpl) ListExpenses(groupID string, page entities.PageRequest) (*entities.Page[entities.GroupExpense], error) {
+	filter, err := groupExpensesFilter(groupID)
+	if err != nil {
+		return nil, err
+	}
+	return paginate[entities.GroupExpense](r.db, filter, page, groupExpenseListSpec)
+}
+
+// GetAllExpenses returns every expense and settlement of the group, for
+// computing balances
+func (r *GroupExpenseRepositoryImpl) GetAllExpenses(groupID string) ([]entities.GroupExpense, error) {
+	filter, err := groupExpensesFilter(groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := r.db.Find(context.TODO(), filter)
+	if err != nil {
+		return nil, err
+	}
+	expenses := []entities.GroupExpense{}
+	if err := cursor.All(context.TODO(), &expenses); err != nil {
+		return nil, err
+	}
+
+	return expenses, nil
+}
+
+func (r *GroupExpenseRepositoryImpl) DeleteExpense(id string, groupID string) error {
+	filter, err := groupExpensesFilter(groupID)
+	if err != nil {
+		return err
+	}
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return errors.New("invalid expense id")
+	}
+	filter["_id"] = objectID
+
+	result, err := r.db.DeleteOne(context.TODO(), filter)
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return errors.New("expense not found")
+	}
+
+	return nil
+}
+
+func (r *GroupExpenseRepositoryImpl) DeleteExpensesByGroup(groupID string) error {
+	filter, err := groupExpensesFilter(groupID)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.DeleteMany(context.TODO(), filter)
+	return err
+}
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ownerFilter matches the document with the given id only if it belongs to userID
+func ownerFilter(id, userID, entity string) (bson.M, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, errors.New("invalid " + entity + " id")
+	}
+	ownerID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, errors.New("invalid user id")
+	}
+	return bson.M{"_id": objectID, "user_id": ownerID}, nil
+}
+
+// userFilter matches every document that belongs to userID
+func userFilter(userID string) (bson.M, error) {
+	ownerID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, errors.New("invalid user id")
+	}
+	return bson.M{"user_id": ownerID}, nil
+}
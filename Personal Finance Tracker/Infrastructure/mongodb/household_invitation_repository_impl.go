@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"personal-finance-tracker/domain/entities"
+	repoInterface "personal-finance-tracker/domain/interface"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type HouseholdInvitationRepositoryImpl struct {
+	db *mongo.Collection
+}
+
+func NewHouseholdInvitationRepository(db *mongo.Collection) repoInterface.HouseholdInvitationRepository {
+	return &HouseholdInvitationRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *HouseholdInvitationRepositoryImpl) CreateInvitation(invitation *entities.HouseholdInvitation) (*entities.HouseholdInvitation, error) {
+	result, err := r.db.InsertOne(context.TODO(), invitation)
+	if err != nil {
+		return nil, err
+	}
+	invitation.ID = result.InsertedID.(primitive.ObjectID)
+	return invitation, nil
+}
+
+func (r *HouseholdInvitationRepositoryImpl) GetInvitationByTokenHash(tokenHash string) (*entities.HouseholdInvitation, error) {
+	var invitation entities.HouseholdInvitation
+	err := r.db.FindOne(context.TODO(), bson.M{"token_hash": tokenHash}).Decode(&invitation)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errors.New("invitation not found")
+		}
+		return nil, err
+	}
+
+	return &invitation, nil
+}
+
+func (r *HouseholdInvitationRepositoryImpl) ListInvitations(householdID string) ([]entities.HouseholdInvitation, error) {
+	filter, err := invitationHouseholdFilter(householdID)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := r.db.Find(context.TODO(), filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	invitations := []entities.HouseholdInvitation{}
+	if err := cursor.All(context.TODO(), &invitations); err != nil {
+		return nil, err
+	}
+
+	return invitations, nil
+}
+
+func (r *HouseholdInvitationRepositoryImpl) DeleteInvitation(id string, householdID string) error {
+	filter, err := invitationHouseholdFilter(householdID)
+	if err != nil {
+		return err
+	}
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return errors.New("invalid invitation id")
+	}
+	filter["_id"] = objectID
+
+	result, err := r.db.DeleteOne(context.TODO(), filter)
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return errors.New("invitation not found")
+	}
+
+	return nil
+}
+
+func (r *HouseholdInvitationRepositoryImpl) DeleteInvitationsByHousehold(householdID string) error {
+	filter, err := invitationHouseholdFilter(householdID)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.DeleteMany(context.TODO(), filter)
+	return err
+}
+
+func (r *HouseholdInvitationRepositoryImpl) DeleteInvitationsByEmail(householdID string, email string) error {
+	filter, err := invitationHouseholdFilter(householdID)
+	if err != nil {
+		return err
+	}
+	filter["email"] = email
+
+	_, err = r.db.DeleteMany(context.TODO(), filter)
+	return err
+}
+
+func invitationHouseholdFilter(householdID string) (bson.M, error) {
+	objectID, err := primitive.ObjectIDFromHex(householdID)
+	if err != nil {
+		return nil, errors.New("invalid household id")
+	}
+	return bson.M{"household_id": objectID}, nil
+}
@@ -0,0 +1,205 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"personal-finance-tracker/domain/entities"
+	repoInterface "personal-finance-tracker/domain/interface"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var householdListSpec = ListSpec{
+	SortFields: map[string]string{
+		"name":       "name",
+		"created_at": "created_at",
+	},
+	DefaultSort: "name",
+	Fields: map[string]string{
+		"name":       "name",
+		"members":    "members",
+		"created_by": "created_by",
+		"created_at": "created_at",
+		"updated_at": "updated_at",
+	},
+}
+
+// Households are shared, so access is checked against the member list by
+// the use case rather than by a user_id filter here
+type HouseholdRepositoryImpl struct {
+	db *mongo.Collection
+}
+
+func NewHouseholdRepository(db *mongo.Collection) repoInterface.HouseholdRepository {
+	return &HouseholdRepositoryImpl{
+		db: db,
+	}
+}
+
+func householdFilter(id string) (bson.M, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, errors.New("invalid household id")
+	}
+	return bson.M{"_id": objectID}, nil
+}
+
+func (r *HouseholdRepositoryImpl) CreateHousehold(household *entities.Household) (*entities.Household, error) {
+	result, err := r.db.InsertOne(context.TODO(), household)
+	if err != nil {
+		return nil, err
+	}
+	household.ID = result.InsertedID.(primitive.ObjectID)
+	return household, nil
+}
+
+func (r *HouseholdRepositoryImpl) GetHouseholdByID(id string) (*entities.Household, error) {
+	filter, err := householdFilter(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var household entities.Household
+	err = r.db.FindOne(context.TODO(), filter).Decode(&household)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errors.New("household not found")
+		}
+		return nil, err
+	}
+
+	return &household, nil
+}
+
+// ListHouseholds lists the households the user is a member of
+func (r *HouseholdRepositoryImpl) ListHouseholds(userID string, page entities.PageRequest) (*entities.Page[entities.Household], error) {
+	memberID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, errors.New("invalid user id")
+	}
+	return paginate[entities.Household](r.db, bson.M{"members.user_id": memberID}, page, householdListSpec)
+}
+
+func (r *HouseholdRepositoryImpl) UpdateHousehold(household *entities.Household) (*entities.Household, error) {
+	filter := bson.M{"_id": household.ID}
+	update := bson.M{
+		"$set": bson.M{
+			"name":       household.Name,
+			"updated_at": household.UpdatedAt,
+		},
+	}
+
+	return r.findOneAndUpdate(filter, update)
+}
+
+func (r *HouseholdRepositoryImpl) DeleteHousehold(id string) error {
+	filter, err := householdFilter(id)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.DeleteOne(context.TODO(), filter)
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return errors.New("household not found")
+	}
+
+	return nil
+}
+
+// AddMember adds the member unless the user already belongs to the household
+func (r *HouseholdRepositoryImpl) AddMember(householdID string, member entities.HouseholdMember) (*entities.Household, error) {
+	filter, err := householdFilter(householdID)
+	if err != nil {
+		return nil, err
+	}
+	filter["members.user_id"] = bson.M{"$ne": member.UserID}
+	update := bson.M{
+		"$push": bson.M{"members": member},
+		"$set":  bson.M{"updated_at": time.Now()},
+	}
+
+	household, err := r.findOneAndUpdate(filter, update)
+	if err != nil {
+		if err.Error() == "household not found" {
+			return nil, errors.New("already a member of this household")
+		}
+		return nil, err
+	}
+	return household, nil
+}
+
+func (r *HouseholdRepositoryImpl) UpdateMemberRole(householdID string, userID string, role string) (*entities.Household, error) {
+	filter, err := householdFilter(householdID)
+	if err != nil {
+		return nil, err
+	}
+	memberID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, errors.New("invalid user id")
+	}
+	filter["members.user_id"] = memberID
+	update := bson.M{
+		"$set": bson.M{
+			"members.$.role": role,
+			"updated_at":     time.Now(),
+		},
+	}
+
+	household, err := r.findOneAndUpdate(filter, update)
+	if err != nil {
+		if err.Error() == "household not found" {
+			return nil, errors.New("member not found")
+		}
+		return nil, err
+	}
+	return household, nil
+}
+
+func (r *HouseholdRepositoryImpl) RemoveMember(householdID string, userID string) (*entities.Household, error) {
+	filter, err := householdFilter(householdID)
+	if err != nil {
+		return nil, err
+	}
+	memberID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, errors.New("invalid user id")
+	}
+	filter["members.user_id"] = memberID
+	update := bson.M{
+		"$pull": bson.M{"members": bson.M{"user_id": memberID}},
+		"$set":  bson.M{"updated_at": time.Now()},
+	}
+
+	household, err := r.findOneAndUpdate(filter, update)
+	if err != nil {
+		if err.Error() == "household not found" {
+			return nil, errors.New("member not found")
+		}
+		return nil, err
+	}
+	return household, nil
+}
+
+func (r *HouseholdRepositoryImpl) findOneAndUpdate(filter bson.M, update bson.M) (*entities.Household, error) {
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var household entities.Household
+	err := r.db.FindOneAndUpdate(context.TODO(), filter, update, opts).Decode(&household)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errors.New("household not found")
+		}
+		return nil, err
+	}
+
+	return &household, nil
+}
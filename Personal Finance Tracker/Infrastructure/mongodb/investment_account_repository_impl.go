@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"personal-finance-tracker/domain/entities"
+	repoInterface "personal-finance-tracker/domain/interface"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var investmentAccountListSpec = ListSpec{
+	SortFields: map[string]string{
+		"name":       "name",
+		"created_at": "created_at",
+	},
+	DefaultSort: "name",
+	Fields: map[string]string{
+		"name":              "name",
+		"broker":            "broker",
+		"currency":          "currency",
+		"cost_basis_method": "cost_basis_method",
+		"created_at":        "created_at",
+		"updated_at":        "updated_at",
+	},
+}
+
+type InvestmentAccountRepositoryImpl struct {
+	db *mongo.Collection
+}
+
+func NewInvestmentAccountRepository(db *mongo.Collection) repoInterface.InvestmentAccountRepository {
+	return &InvestmentAccountRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *InvestmentAccountRepositoryImpl) CreateAccount(account *entities.InvestmentAccount) (*entities.InvestmentAccount, error) {
+	result, err := r.db.InsertOne(context.TODO(), account)
+	if err != nil {
+		return nil, err
+	}
+	account.ID = result.InsertedID.(primitive.ObjectID)
+	return account, nil
+}
+
+func (r *InvestmentAccountRepositoryImpl) GetAccountByID(id string, userID string) (*entities.InvestmentAccount, error) {
+	filter, err := ownerFilter(id, userID, "account")
+	if err != nil {
+		return nil, err
+	}
+
+	var account entities.InvestmentAccount
+	err = r.db.FindOne(context.TODO(), filter).Decode(&account)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errors.New("investment account not found")
+		}
+		return nil, err
+	}
+
+	return &account, nil
+}
+
+func (r *InvestmentAccountRepositoryImpl) ListAccounts(userID string, page entities.PageRequest) (*entities.Page[entities.InvestmentAccount], error) {
+	filter, err := userFilter(userID)
+	if err != nil {
+		return nil, err
+	}
+	return paginate[entities.InvestmentAccount](r.db, filter, page, investmentAccountListSpec)
+}
+
+func (r *InvestmentAccountRepositoryImpl) UpdateAccount(account *entities.InvestmentAccount) (*entities.InvestmentAccount, error) {
+	filter := bson.M{"_id": account.ID, "user_id": account.UserID}
+	update := bson.M{
+		"$set": bson.M{
+			"name":              account.Name,
+			"broker":            account.Broker,
+			"currency":          account.Currency,
+			"cost_basis_method": account.CostBasisMethod,
+			"updated_at":        account.UpdatedAt,
+		},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var updated entities.InvestmentAccount
+	err := r.db.FindOneAndUpdate(context.TODO(), filter, update, opts).Decode(&updated)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errors.New("investment account not found")
+		}
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+func (r *InvestmentAccountRepositoryImpl) DeleteAccount(id string, userID string) error {
+	filter, err := ownerFilter(id, userID, "account")
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.DeleteOne(context.TODO(), filter)
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return errors.New("investment account not found")
+	}
+
+	return nil
+}
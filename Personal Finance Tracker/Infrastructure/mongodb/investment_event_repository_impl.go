@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"personal-finance-tracker/domain/entities"
+	repoInterface "personal-finance-tracker/domain/interface"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var investmentEventListSpec = ListSpec{
+	SortFields: map[string]string{
+		"date":   "date",
+		"symbol": "symbol",
+	},
+	DefaultSort: "-date",
+	Fields: map[string]string{
+		"symbol":      "symbol",
+		"type":        "type",
+		"date":        "date",
+		"quantity":    "quantity",
+		"price":       "price",
+		"fees":        "fees",
+		"amount":      "amount",
+		"split_ratio": "split_ratio",
+		"lots":        "lots",
+		"notes":       "notes",
+	},
+}
+
+type InvestmentEventRepositoryImpl struct {
+	db *mongo.Collection
+}
+
+func NewInvestmentEventRepository(db *mongo.Collection) repoInterface.InvestmentEventRepository {
+	return &InvestmentEventRepositoryImpl{
+		db: db,
+	}
+}
+
+// accountFilter matches the events of one account owned by userID
+func accountFilter(accountID, userID string) (bson.M, error) {
+	filter, err := userFilter(userID)
+	if err != nil {
+		return nil, err
+	}
+	objectID, err := primitive.ObjectIDFromHex(accountID)
+	if err != nil {
+		return nil, errors.New("invalid account id")
+	}
+	filter["account_id"] = objectID
+	return filter, nil
+}
+
+func (r *InvestmentEventRepositoryImpl) CreateEvent(event *entities.InvestmentEvent) (*entities.InvestmentEvent, error) {
+	result, err := r.db.InsertOne(context.TODO(), event)
+	if err != nil {
+		return nil, err
+	}
+	event.ID = result.InsertedID.(primitive.ObjectID)
+	return event, nil
+}
+
+func (r *InvestmentEventRepositoryImpl) ListEvents(accountID string, userID string, page entities.PageRequest) (*entities.Page[entities.InvestmentEvent], error) {
+	filter, err := accountFilter(accountID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return paginate[entities.InvestmentEvent](r.db, filter, page, investmentEventListSpec)
+}
+
+// GetAllEvents returns the account's events in the order they happened
+func (r *InvestmentEventRepositoryImpl) GetAllEvents(accountID string, userID string) ([]entities.InvestmentEvent, error) {
+	filter, err := accountFilter(accountID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return r.findSorted(filter)
+}
+
+func (r *InvestmentEventRepositoryImpl) findSorted(filter bson.M) ([]entities.InvestmentEvent, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "date", Value: 1}, {Key: "_id", Value: 1}})
+
+	cursor, err := r.db.Find(context.TODO(), filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	events := []entities.InvestmentEvent{}
+	if err := cursor.All(context.TODO(), &events); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// GetAllUserEvents returns the events of every account of the user in the
+// order they happened
+func (r *InvestmentEventRepositoryImpl) GetAllUserEvents(userID string) ([]entities.InvestmentEvent, error) {
+	filter, err := userFilter(userID)
+	if err != nil {
+		return nil, err
+	}
+	return r.findSorted(filter)
+}
+
+func (r *InvestmentEventRepositoryImpl) DeleteEvent(id string, accountID string, userID string) error {
+	filter, err := accountFilter(accountID, userID)
+	if err != nil {
+		return err
+	}
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return errors.New("invalid event id")
+	}
+	filter["_id"] = objectID
+
+	result, err := r.db.DeleteOne(context.TODO(), filter)
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return errors.New("investment event not found")
+	}
+
+	return nil
+}
+
+func (r *InvestmentEventRepositoryImpl) DeleteEventsByAccount(accountID string, userID string) error {
+	filter, err := accountFilter(accountID, userID)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.DeleteMany(context.TODO(), filter)
+	return err
+}
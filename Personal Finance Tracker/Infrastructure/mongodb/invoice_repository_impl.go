@@ -0,0 +1,319 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"personal-finance-tracker/domain/entities"
+	repoInterface "personal-finance-tracker/domain/interface"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var invoiceListSpec = ListSpec{
+	SortFields: map[string]string{
+		"number":     "number",
+		"issue_date": "issue_date",
+		"due_date":   "due_date",
+		"total":      "total",
+		"created_at": "created_at",
+	},
+	DefaultSort: "-created_at",
+	Fields: map[string]string{
+		"number":      "number",
+		"status":      "status",
+		"client":      "client",
+		"currency":    "currency",
+		"issue_date":  "issue_date",
+		"due_date":    "due_date",
+		"items":       "items",
+		"tax_lines":   "tax_lines",
+		"subtotal":    "subtotal",
+		"tax_total":   "tax_total",
+		"total":       "total",
+		"amount_paid": "amount_paid",
+		"amount_due":  "amount_due",
+		"payments":    "payments",
+		"notes":       "notes",
+		"sent_at":     "sent_at",
+		"paid_at":     "paid_at",
+		"created_at":  "created_at",
+		"updated_at":  "updated_at",
+	},
+}
+
+type InvoiceRepositoryImpl struct {
+	db       *mongo.Collection
+	counters *mongo.Collection
+}
+
+// NewInvoiceRepository stores invoices in db and each user's last invoice
+// sequence number in counters
+func NewInvoiceRepository(db *mongo.Collection, counters *mongo.Collection) repoInterface.InvoiceRepository {
+	return &InvoiceRepositoryImpl{
+		db:       db,
+		counters: counters,
+	}
+}
+
+func (r *InvoiceRepositoryImpl) CreateInvoice(invoice *entities.Invoice) (*entities.Invoice, error) {
+	result, err := r.db.InsertOne(context.TODO(), invoice)
+	if err != nil {
+		return nil, err
+	}
+	invoice.ID = result.InsertedID.(primitive.ObjectID)
+	return invoice, nil
+}
+
+func (r *InvoiceRepositoryImpl) GetInvoiceByID(id string, userID string) (*entities.Invoice, error) {
+	filter, err := ownerFilter(id, userID, "invoice")
+	if err != nil {
+		return nil, err
+	}
+
+	var invoice entities.Invoice
+	err = r.db.FindOne(context.TODO(), filter).Decode(&invoice)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errors.New("invoice not found")
+		}
+		return nil, err
+	}
+
+	return &invoice, nil
+}
+
+// ListInvoices pages through the user's invoices, optionally only those
+// with the given status
+func (r *InvoiceRepositoryImpl) ListInvoices(userID string, status string, page entities.PageRequest) (*entities.Page[entities.Invoice], error) {
+	filter, err := userFilter(userID)
+	if err != nil {
+		return nil, err
+	}
+	if status != "" {
+		filter["status"] = status
+	}
+	return paginate[entities.Invoice](r.db, filter, page, invoiceListSpec)
+}
+
+// GetOpenInvoices returns the user's issued invoices that are not fully
+// paid, oldest due date first
+func (r *InvoiceRepositoryImpl) GetOpenInvoices(userID string) ([]entities.Invoice, error) {
+	filter, err := userFilter(userID)
+	if err != nil {
+		return nil, err
+	}
+	filter["status"] = bson.M{"$in": []string{entities.InvoiceStatusSent, entities.InvoiceStatusPartiallyPaid}}
+
+	opts := options.Find().SetSort(bson.D{{Key: "due_date", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := r.db.Find(context.TODO(), filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	invoices := []entities.Invoice{}
+	if err := cursor.All(context.TODO(), &invoices); err != nil {
+		return nil, err
+	}
+
+	return invoices, nil
+}
+
+// UpdateInvoice saves the contents of a draft; status, number and payments
+// change through IssueInvoice, VoidInvoice, AddPayment and RemovePayment only
+func (r *InvoiceRepositoryImpl) UpdateInvoice(invoice *entities.Invoice) (*entities.Invoice, error) {
+	filter := bson.M{"_id": invoice.ID, "user_id": invoice.UserID}
+	update := bson.M{
+		"$set": bson.M{
+			"client":     invoice.Client,
+			"currency":   invoice.Currency,
+			"issue_date": invoice.IssueDate,
+			"due_date":   invoice.DueDate,
+			"items":      invoice.Items,
+			"tax_lines":  invoice.TaxLines,
+			"subtotal":   invoice.Subtotal,
+			"tax_total":  invoice.TaxTotal,
+			"total":      invoice.Total,
+			"amount_due": invoice.AmountDue,
+			"notes":      invoice.Notes,
+			"updated_at": invoice.UpdatedAt,
+		},
+	}
+
+	return r.findOneAndUpdate(filter, update)
+}
+
+// SetInvoiceSentAt records a delivery without touching the status, which
+// payments may have changed while the email was being sent
+func (r *InvoiceRepositoryImpl) SetInvoiceSentAt(id string, userID string, sentAt time.Time) (*entities.Invoice, error) {
+	filter, err := ownerFilter(id, userID, "invoice")
+	if err != nil {
+		return nil, err
+	}
+	update := bson.M{"$set": bson.M{"sent_at": sentAt, "updated_at": time.Now()}}
+
+	return r.findOneAndUpdate(filter, update)
+}
+
+func (r *InvoiceRepositoryImpl) VoidInvoice(id string, userID string) (*entities.Invoice, error) {
+	filter, err := ownerFilter(id, userID, "invoice")
+	if err != nil {
+		return nil, err
+	}
+	filter["status"] = entities.InvoiceStatusSent
+	filter["payments"] = bson.M{"$size": 0}
+	update := bson.M{"$set": bson.M{"status": entities.InvoiceStatusVoid, "updated_at": time.Now()}}
+
+	return r.findOneAndUpdate(filter, update)
+}
+
+func (r *InvoiceRepositoryImpl) DeleteInvoice(id string, userID string) error {
+	filter, err := ownerFilter(id, userID, "invoice")
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.DeleteOne(context.TODO(), filter)
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return errors.New("invoice not found")
+	}
+
+	return nil
+}
+
+// IssueInvoice only updates a draft, so of two concurrent issues of the same
+// invoice one gets "invoice not found"
+func (r *InvoiceRepositoryImpl) IssueInvoice(invoice *entities.Invoice) (*entities.Invoice, error) {
+	filter := bson.M{"_id": invoice.ID, "user_id": invoice.UserID, "status": entities.InvoiceStatusDraft}
+	update := bson.M{
+		"$set": bson.M{
+			"number":     invoice.Number,
+			"status":     invoice.Status,
+			"updated_at": invoice.UpdatedAt,
+		},
+	}
+
+	return r.findOneAndUpdate(filter, update)
+}
+
+// AddPayment only applies to an open invoice with at least the payment
+// still due, so concurrent payments cannot overpay it. Amounts are in whole
+// cents; the half cent absorbs floating point drift in amount_due.
+func (r *InvoiceRepositoryImpl) AddPayment(invoiceID string, userID string, payment entities.InvoicePayment) (*entities.Invoice, error) {
+	filter, err := ownerFilter(invoiceID, userID, "invoice")
+	if err != nil {
+		return nil, err
+	}
+	filter["status"] = bson.M{"$in": bson.A{entities.InvoiceStatusSent, entities.InvoiceStatusPartiallyPaid}}
+	filter["amount_due"] = bson.M{"$gte": payment.Amount - 0.005}
+	update := bson.A{
+		bson.M{"$set": bson.M{
+			// $literal keeps a reference starting with "$" from being read
+			// as a field path
+			"payments":    bson.M{"$concatArrays": bson.A{"$payments", bson.M{"$literal": bson.A{payment}}}},
+			"amount_paid": bson.M{"$add": bson.A{"$amount_paid", payment.Amount}},
+			"amount_due":  bson.M{"$subtract": bson.A{"$amount_due", payment.Amount}},
+			"updated_at":  time.Now(),
+		}},
+		paymentStatusStage,
+	}
+
+	return r.findOneAndUpdate(filter, update)
+}
+
+func (r *InvoiceRepositoryImpl) RemovePayment(invoiceID string, userID string, payment entities.InvoicePayment) (*entities.Invoice, error) {
+	filter, err := ownerFilter(invoiceID, userID, "invoice")
+	if err != nil {
+		return nil, err
+	}
+	filter["payments._id"] = payment.ID
+	update := bson.A{
+		bson.M{"$set": bson.M{
+			"payments":    bson.M{"$filter": bson.M{"input": "$payments", "cond": bson.M{"$ne": bson.A{"$$this._id", payment.ID}}}},
+			"amount_paid": bson.M{"$subtract": bson.A{"$amount_paid", payment.Amount}},
+			"amount_due":  bson.M{"$add": bson.A{"$amount_due", payment.Amount}},
+			"updated_at":  time.Now(),
+		}},
+		paymentStatusStage,
+	}
+
+	invoice, err := r.findOneAndUpdate(filter, update)
+	if err != nil {
+		if err.Error() == "invoice not found" {
+			return nil, errors.New("payment not found")
+		}
+		return nil, err
+	}
+	return invoice, nil
+}
+
+// paymentStatusStage derives the status from the amount still due after a
+// payment update: paid once nothing is due, with the latest payment date,
+// partially paid while payments fall short and sent without any
+var paymentStatusStage = bson.M{"$set": bson.M{
+	"status": bson.M{"$switch": bson.M{
+		"branches": bson.A{
+			bson.M{"case": bson.M{"$lt": bson.A{"$amount_due", 0.005}}, "then": entities.InvoiceStatusPaid},
+			bson.M{"case": bson.M{"$gt": bson.A{bson.M{"$size": "$payments"}, 0}}, "then": entities.InvoiceStatusPartiallyPaid},
+		},
+		"default": entities.InvoiceStatusSent,
+	}},
+	"paid_at": bson.M{"$cond": bson.A{
+		bson.M{"$lt": bson.A{"$amount_due", 0.005}},
+		bson.M{"$max": "$payments.date"},
+		nil,
+	}},
+}}
+
+// NextInvoiceSequence atomically increments and returns the user's invoice
+// counter, starting at 1
+func (r *InvoiceRepositoryImpl) NextInvoiceSequence(userID string) (int64, error) {
+	ownerID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return 0, errors.New("invalid user id")
+	}
+
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	var counter struct {
+		Sequence int64 `bson:"sequence"`
+	}
+	err = r.counters.FindOneAndUpdate(context.TODO(), bson.M{"_id": ownerID}, bson.M{"$inc": bson.M{"sequence": 1}}, opts).Decode(&counter)
+	if err != nil {
+		return 0, err
+	}
+
+	return counter.Sequence, nil
+}
+
+func (r *InvoiceRepositoryImpl) ReleaseInvoiceSequence(userID string, sequence int64) error {
+	ownerID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return errors.New("invalid user id")
+	}
+
+	_, err = r.counters.UpdateOne(context.TODO(), bson.M{"_id": ownerID, "sequence": sequence}, bson.M{"$inc": bson.M{"sequence": -1}})
+	return err
+}
+
+// findOneAndUpdate takes an update document or, for payments, a pipeline
+func (r *InvoiceRepositoryImpl) findOneAndUpdate(filter bson.M, update interface{}) (*entities.Invoice, error) {
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var invoice entities.Invoice
+	err := r.db.FindOneAndUpdate(context.TODO(), filter, update, opts).Decode(&invoice)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errors.New("invoice not found")
+		}
+		return nil, err
+	}
+
+	return &invoice, nil
+}
@@ -0,0 +1,184 @@
+package repository
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strings"
+
+	"personal-finance-tracker/domain/entities"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ListSpec describes which fields of a collection can be sorted on and
+// selected with fields=. Keys are the API names, values the bson field names.
+// Sort fields must be present on every document so cursors stay stable.
+type ListSpec struct {
+	SortFields  map[string]string
+	DefaultSort string
+	Fields      map[string]string
+	Hidden      []string // never returned, e.g. password hashes
+}
+
+// pageCursor is the decoded form of an opaque cursor
+type pageCursor struct {
+	Sort  string             `bson:"s"`
+	Value interface{}        `bson:"v"`
+	ID    primitive.ObjectID `bson:"id"`
+}
+
+// paginate runs a cursor-paginated find on coll. Results are ordered by the
+// requested sort field with _id as tie-breaker, so pages never overlap.
+func paginate[T any](coll *mongo.Collection, filter bson.M, page entities.PageRequest, spec ListSpec) (*entities.Page[T], error) {
+	limit := page.Limit
+	if limit <= 0 {
+		limit = entities.DefaultPageLimit
+	}
+	if limit > entities.MaxPageLimit {
+		limit = entities.MaxPageLimit
+	}
+
+	sortKey := page.Sort
+	if sortKey == "" {
+		sortKey = spec.DefaultSort
+	}
+	direction := 1
+	name := sortKey
+	if strings.HasPrefix(name, "-") {
+		direction = -1
+		name = strings.TrimPrefix(name, "-")
+	}
+	sortField, ok := spec.SortFields[name]
+	if !ok {
+		return nil, errors.New("invalid sort field: " + name)
+	}
+
+	query := filter
+	if query == nil {
+		query = bson.M{}
+	}
+	if page.Cursor != "" {
+		cursor, err := decodeCursor(page.Cursor)
+		if err != nil || cursor.Sort != sortKey {
+			return nil, errors.New("invalid cursor")
+		}
+		op := "$gt"
+		if direction < 0 {
+			op = "$lt"
+		}
+		var after bson.M
+		if sortField == "_id" {
+			after = bson.M{"_id": bson.M{op: cursor.ID}}
+		} else {
+			after = bson.M{"$or": bson.A{
+				bson.M{sortField: bson.M{op: cursor.Value}},
+				bson.M{sortField: cursor.Value, "_id": bson.M{op: cursor.ID}},
+			}}
+		}
+		query = bson.M{"$and": bson.A{query, after}}
+	}
+
+	projection, err := buildProjection(page.Fields, sortField, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: sortField, Value: direction}, {Key: "_id", Value: direction}}).
+		SetLimit(limit + 1)
+	if projection != nil {
+		opts.SetProjection(projection)
+	}
+
+	cur, err := coll.Find(context.TODO(), query, opts)
+	if err != nil {
+		return nil, err
+	}
+	var docs []bson.Raw
+	if err := cur.All(context.TODO(), &docs); err != nil {
+		return nil, err
+	}
+
+	result := &entities.Page[T]{Items: []T{}}
+	if int64(len(docs)) > limit {
+		docs = docs[:limit]
+		result.HasMore = true
+	}
+	for _, doc := range docs {
+		var item T
+		if err := bson.Unmarshal(doc, &item); err != nil {
+			return nil, err
+		}
+		result.Items = append(result.Items, item)
+	}
+
+	if result.HasMore {
+		last := docs[len(docs)-1]
+		next, err := encodeCursor(sortKey, sortField, last)
+		if err != nil {
+			return nil, err
+		}
+		result.NextCursor = next
+	}
+
+	return result, nil
+}
+
+// buildProjection turns the requested fields into a Mongo projection.
+// The sort field and _id are always included since the next cursor needs them.
+func buildProjection(fields []string, sortField string, spec ListSpec) (bson.M, error) {
+	if len(fields) == 0 {
+		if len(spec.Hidden) == 0 {
+			return nil, nil
+		}
+		projection := bson.M{}
+		for _, field := range spec.Hidden {
+			projection[field] = 0
+		}
+		return projection, nil
+	}
+
+	projection := bson.M{"_id": 1, sortField: 1}
+	for _, name := range fields {
+		field, ok := spec.Fields[name]
+		if !ok {
+			return nil, errors.New("invalid field: " + name)
+		}
+		projection[field] = 1
+	}
+	return projection, nil
+}
+
+func encodeCursor(sortKey, sortField string, doc bson.Raw) (string, error) {
+	id, ok := doc.Lookup("_id").ObjectIDOK()
+	if !ok {
+		return "", errors.New("document has no object id")
+	}
+	value := doc.Lookup(strings.Split(sortField, ".")...)
+
+	data, err := bson.Marshal(bson.D{
+		{Key: "s", Value: sortKey},
+		{Key: "v", Value: value},
+		{Key: "id", Value: id},
+	})
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodeCursor(token string) (*pageCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, err
+	}
+	var cursor pageCursor
+	if err := bson.Unmarshal(data, &cursor); err != nil {
+		return nil, err
+	}
+	return &cursor, nil
+}
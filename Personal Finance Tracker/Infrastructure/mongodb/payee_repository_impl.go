@@ -0,0 +1,182 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"personal-finance-tracker/domain/entities"
+	repoInterface "personal-finance-tracker/domain/interface"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var payeeListSpec = ListSpec{
+	SortFields: map[string]string{
+		"name":       "normalized_name",
+		"created_at": "created_at",
+	},
+	DefaultSort: "name",
+	Fields: map[string]string{
+		"name":             "name",
+		"normalized_name":  "normalized_name",
+		"aliases":          "aliases",
+		"default_category": "default_category",
+		"logo_url":         "logo_url",
+		"color":            "color",
+		"created_at":       "created_at",
+		"updated_at":       "updated_at",
+	},
+}
+
+type PayeeRepositoryImpl struct {
+	db *mongo.Collection
+}
+
+func NewPayeeRepository(db *mongo.Collection) repoInterface.PayeeRepository {
+	return &PayeeRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *PayeeRepositoryImpl) CreatePayee(payee *entities.Payee) (*entities.Payee, error) {
+	result, err := r.db.InsertOne(context.TODO(), payee)
+	if err != nil {
+		return nil, err
+	}
+	payee.ID = result.InsertedID.(primitive.ObjectID)
+	return payee, nil
+}
+
+func (r *PayeeRepositoryImpl) GetPayeeByID(id string, userID string) (*entities.Payee, error) {
+	filter, err := ownerFilter(id, userID, "payee")
+	if err != nil {
+		return nil, err
+	}
+
+	var payee entities.Payee
+	err = r.db.FindOne(context.TODO(), filter).Decode(&payee)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errors.New("payee not found")
+		}
+		return nil, err
+	}
+
+	return &payee, nil
+}
+
+func (r *PayeeRepositoryImpl) ListPayees(userID string, page entities.PageRequest) (*entities.Page[entities.Payee], error) {
+	filter, err := userFilter(userID)
+	if err != nil {
+		return nil, err
+	}
+	return paginate[entities.Payee](r.db, filter, page, payeeListSpec)
+}
+
+// GetAllPayees returns the user's whole directory, for matching descriptions
+func (r *PayeeRepositoryImpl) GetAllPayees(userID string) ([]entities.Payee, error) {
+	filter, err := userFilter(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, err := r.db.Find(context.TODO(), filter)
+	if err != nil {
+		return nil, err
+	}
+	payees := []entities.Payee{}
+	if err := cursor.All(context.TODO(), &payees); err != nil {
+		return nil, err
+	}
+
+	return payees, nil
+}
+
+// UpdatePayee saves the editable fields; aliases are changed through
+// AddAliases and RemoveAlias only
+func (r *PayeeRepositoryImpl) UpdatePayee(payee *entities.Payee) (*entities.Payee, error) {
+	filter := bson.M{"_id": payee.ID, "user_id": payee.UserID}
+	update := bson.M{
+		"$set": bson.M{
+			"name":             payee.Name,
+			"normalized_name":  payee.NormalizedName,
+			"default_category": payee.DefaultCategory,
+			"logo_url":         payee.LogoURL,
+			"color":            payee.Color,
+			"updated_at":       payee.UpdatedAt,
+		},
+	}
+
+	return r.findOneAndUpdate(filter, update)
+}
+
+func (r *PayeeRepositoryImpl) DeletePayee(id string, userID string) error {
+	filter, err := ownerFilter(id, userID, "payee")
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.DeleteOne(context.TODO(), filter)
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return errors.New("payee not found")
+	}
+
+	return nil
+}
+
+func (r *PayeeRepositoryImpl) AddAliases(id string, userID string, aliases []string) (*entities.Payee, error) {
+	filter, err := ownerFilter(id, userID, "payee")
+	if err != nil {
+		return nil, err
+	}
+	update := bson.M{
+		"$addToSet": bson.M{"aliases": bson.M{"$each": aliases}},
+		"$set":      bson.M{"updated_at": time.Now()},
+	}
+
+	return r.findOneAndUpdate(filter, update)
+}
+
+func (r *PayeeRepositoryImpl) RemoveAlias(id string, userID string, alias string) (*entities.Payee, error) {
+	filter, err := ownerFilter(id, userID, "payee")
+	if err != nil {
+		return nil, err
+	}
+	filter["aliases"] = alias
+	update := bson.M{
+		"$pull": bson.M{"aliases": alias},
+		"$set":  bson.M{"updated_at": time.Now()},
+	}
+
+	payee, err := r.findOneAndUpdate(filter, update)
+	if err != nil {
+		if err.Error() == "payee not found" {
+			return nil, errors.New("alias not found")
+		}
+		return nil, err
+	}
+	return payee, nil
+}
+
+func (r *PayeeRepositoryImpl) findOneAndUpdate(filter bson.M, update bson.M) (*entities.Payee, error) {
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var payee entities.Payee
+	err := r.db.FindOneAndUpdate(context.TODO(), filter, update, opts).Decode(&payee)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errors.New("payee not found")
+		}
+		return nil, err
+	}
+
+	return &payee, nil
+}
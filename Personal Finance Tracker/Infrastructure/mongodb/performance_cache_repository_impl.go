@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"personal-finance-tracker/domain/entities"
+	repoInterface "personal-finance-tracker/domain/interface"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type PerformanceCacheRepositoryImpl struct {
+	db *mongo.Collection
+}
+
+func NewPerformanceCacheRepository(db *mongo.Collection) repoInterface.PerformanceCacheRepository {
+	return &PerformanceCacheRepositoryImpl{
+		db: db,
+	}
+}
+
+// resultFilter identifies one cached result; accountID is empty for the portfolio
+func resultFilter(userID, scope, accountID string, from, to time.Time) (bson.M, error) {
+	filter, err := userFilter(userID)
+	if err != nil {
+		return nil, err
+	}
+	filter["scope"] = scope
+	filter["from"] = from
+	filter["to"] = to
+	if accountID != "" {
+		objectID, err := primitive.ObjectIDFromHex(accountID)
+		if err != nil {
+			return nil, errors.New("invalid account id")
+		}
+		filter["account_id"] = objectID
+	}
+	return filter, nil
+}
+
+func (r *PerformanceCacheRepositoryImpl) GetResult(userID string, scope string, accountID string, from, to time.Time) (*entities.PerformanceResult, error) {
+	filter, err := resultFilter(userID, scope, accountID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	var result entities.PerformanceResult
+	err = r.db.FindOne(context.TODO(), filter).Decode(&result)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errors.New("performance result not found")
+		}
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (r *PerformanceCacheRepositoryImpl) SaveResult(result *entities.PerformanceResult) error {
+	accountID := ""
+	if result.AccountID != nil {
+		accountID = result.AccountID.Hex()
+	}
+	filter, err := resultFilter(result.UserID.Hex(), result.Scope, accountID, result.From, result.To)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.ReplaceOne(context.TODO(), filter, result, options.Replace().SetUpsert(true))
+	return err
+}
+
+func (r *PerformanceCacheRepositoryImpl) InvalidateUser(userID string) error {
+	filter, err := userFilter(userID)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.DeleteMany(context.TODO(), filter)
+	return err
+}
+
+func (r *PerformanceCacheRepositoryImpl) InvalidateAll() error {
+	_, err := r.db.DeleteMany(context.TODO(), bson.M{})
+	return err
+}
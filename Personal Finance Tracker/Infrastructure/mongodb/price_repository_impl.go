@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"personal-finance-tracker/domain/entities"
+	repoInterface "personal-finance-tracker/domain/interface"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var priceListSpec = ListSpec{
+	SortFields: map[string]string{
+		"date": "date",
+	},
+	DefaultSort: "-date",
+	Fields: map[string]string{
+		"symbol": "symbol",
+		"date":   "date",
+		"close":  "close",
+	},
+}
+
+type PriceRepositoryImpl struct {
+	db *mongo.Collection
+}
+
+func NewPriceRepository(db *mongo.Collection) repoInterface.PriceRepository {
+	return &PriceRepositoryImpl{
+		db: db,
+	}
+}
+
+// UpsertPrices stores prices keyed by symbol and date, so re-importing
+// the same file replaces earlier values instead of duplicating them. The
+// count includes prices that were already stored unchanged.
+func (r *PriceRepositoryImpl) UpsertPrices(prices []entities.SecurityPrice) (int64, error) {
+	if len(prices) == 0 {
+		return 0, nil
+	}
+
+	models := make([]mongo.WriteModel, 0, len(prices))
+	now := time.Now()
+	for _, price := range prices {
+		filter := bson.M{"symbol": price.Symbol, "date": price.Date}
+		update := bson.M{
+			"$set":         bson.M{"close": price.Close},
+			"$setOnInsert": bson.M{"created_at": now},
+		}
+		models = append(models, mongo.NewUpdateOneModel().SetFilter(filter).SetUpdate(update).SetUpsert(true))
+	}
+
+	result, err := r.db.BulkWrite(context.TODO(), models, options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		return 0, err
+	}
+	return result.UpsertedCount + result.MatchedCount, nil
+}
+
+// GetPriceOnOrBefore returns the latest close on or before date
+func (r *PriceRepositoryImpl) GetPriceOnOrBefore(symbol string, date time.Time) (*entities.SecurityPrice, error) {
+	filter := bson.M{
+		"symbol": symbol,
+		"date":   bson.M{"$lte": date},
+	}
+	opts := options.FindOne().SetSort(bson.D{{Key: "date", Value: -1}})
+
+	var price entities.SecurityPrice
+	err := r.db.FindOne(context.TODO(), filter, opts).Decode(&price)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errors.New("price not found")
+		}
+		return nil, err
+	}
+
+	return &price, nil
+}
+
+func (r *PriceRepositoryImpl) ListPrices(symbol string, from, to time.Time, page entities.PageRequest) (*entities.Page[entities.SecurityPrice], error) {
+	filter := bson.M{"symbol": symbol}
+	dateFilter := bson.M{}
+	if !from.IsZero() {
+		dateFilter["$gte"] = from
+	}
+	if !to.IsZero() {
+		dateFilter["$lte"] = to
+	}
+	if len(dateFilter) > 0 {
+		filter["date"] = dateFilter
+	}
+
+	return paginate[entities.SecurityPrice](r.db, filter, page, priceListSpec)
+}
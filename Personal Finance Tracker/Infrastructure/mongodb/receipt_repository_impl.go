@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"personal-finance-tracker/domain/entities"
+	repoInterface "personal-finance-tracker/domain/interface"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var receiptListSpec = ListSpec{
+	SortFields: map[string]string{
+		"created_at": "created_at",
+	},
+	DefaultSort: "-created_at",
+	Fields: map[string]string{
+		"subject":             "subject",
+		"from":                "from",
+		"merchant":            "merchant",
+		"amount":              "amount",
+		"currency":            "currency",
+		"date":                "date",
+		"email_attachment_id": "email_attachment_id",
+		"attachments":         "attachments",
+		"payee_id":            "payee_id",
+		"created_at":          "created_at",
+	},
+}
+
+type ReceiptRepositoryImpl struct {
+	db *mongo.Collection
+}
+
+func NewReceiptRepository(db *mongo.Collection) repoInterface.ReceiptRepository {
+	return &ReceiptRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *ReceiptRepositoryImpl) CreateReceipt(receipt *entities.ParsedReceipt) (*entities.ParsedReceipt, error) {
+	result, err := r.db.InsertOne(context.TODO(), receipt)
+	if err != nil {
+		return nil, err
+	}
+	receipt.ID = result.InsertedID.(primitive.ObjectID)
+	return receipt, nil
+}
+
+func (r *ReceiptRepositoryImpl) GetReceiptByID(id string, userID string) (*entities.ParsedReceipt, error) {
+	filter, err := ownerFilter(id, userID, "receipt")
+	if err != nil {
+		return nil, err
+	}
+
+	var receipt entities.ParsedReceipt
+	err = r.db.FindOne(context.TODO(), filter).Decode(&receipt)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errors.New("receipt not found")
+		}
+		return nil, err
+	}
+
+	return &receipt, nil
+}
+
+func (r *ReceiptRepositoryImpl) ListReceipts(userID string, page entities.PageRequest) (*entities.Page[entities.ParsedReceipt], error) {
+	filter, err := userFilter(userID)
+	if err != nil {
+		return nil, err
+	}
+	return paginate[entities.ParsedReceipt](r.db, filter, page, receiptListSpec)
+}
+
+func (r *ReceiptRepositoryImpl) DeleteReceipt(id string, userID string) error {
+	filter, err := ownerFilter(id, userID, "receipt")
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.DeleteOne(context.TODO(), filter)
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return errors.New("receipt not found")
+	}
+
+	return nil
+}
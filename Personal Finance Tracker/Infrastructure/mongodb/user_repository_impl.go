@@ -12,6 +12,27 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// userListSpec lists the user fields exposed to paginated listings
+var userListSpec = ListSpec{
+	SortFields: map[string]string{
+		"id":         "_id",
+		"email":      "email",
+		"created_at": "created_at",
+	},
+	DefaultSort: "-created_at",
+	Fields: map[string]string{
+		"email":       "email",
+		"name":        "name",
+		"role":        "role",
+		"currency":    "currency",
+		"profile":     "profile",
+		"is_verified": "is_verified",
+		"created_at":  "created_at",
+		"updated_at":  "updated_at",
+	},
+	Hidden: []string{"password"},
+}
+
 type UserRepositoryImpl struct {
 	db *mongo.Collection
 }
@@ -119,3 +140,7 @@ func (r *UserRepositoryImpl) CountUsers() (int64, error) {
 	}
 	return count, nil
 }
+
+func (r *UserRepositoryImpl) ListUsers(page entities.PageRequest) (*entities.Page[entities.User], error) {
+	return paginate[entities.User](r.db, bson.M{}, page, userListSpec)
+}
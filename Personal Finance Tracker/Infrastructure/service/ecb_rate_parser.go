@@ -0,0 +1,130 @@
+package services
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"personal-finance-tracker/domain/entities"
+)
+
+// ECB files use ISO dates in the historical series and "02 January 2006"
+// in the single-day download
+var ecbDateLayouts = []string{"2006-01-02", "02 January 2006", "2 January 2006"}
+
+// ParseECBCSV reads an ECB reference rate CSV (eurofxref.csv or eurofxref-hist.csv)
+func ParseECBCSV(r io.Reader) ([]entities.ExchangeRate, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, errors.New("invalid ECB csv: missing header")
+	}
+	if len(header) < 2 || !strings.EqualFold(strings.TrimSpace(header[0]), "Date") {
+		return nil, errors.New("invalid ECB csv: first column must be Date")
+	}
+
+	var rates []entities.ExchangeRate
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid ECB csv at line %d: %w", line, err)
+		}
+		if len(record) == 0 || strings.TrimSpace(record[0]) == "" {
+			continue
+		}
+
+		date, err := parseECBDate(record[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid ECB csv at line %d: %w", line, err)
+		}
+
+		for i := 1; i < len(record) && i < len(header); i++ {
+			currency := strings.ToUpper(strings.TrimSpace(header[i]))
+			value := strings.TrimSpace(record[i])
+			// Trailing commas and currencies that weren't quoted yet show up as blanks or N/A
+			if currency == "" || value == "" || value == "N/A" {
+				continue
+			}
+			rate, err := strconv.ParseFloat(value, 64)
+			if err != nil || rate <= 0 {
+				return nil, fmt.Errorf("invalid ECB csv at line %d: bad rate %q for %s", line, value, currency)
+			}
+			rates = append(rates, entities.ExchangeRate{
+				Base:   entities.ECBBaseCurrency,
+				Quote:  currency,
+				Date:   date,
+				Rate:   rate,
+				Source: "ecb-csv",
+			})
+		}
+	}
+
+	return rates, nil
+}
+
+type ecbEnvelope struct {
+	Cube struct {
+		Days []struct {
+			Time  string `xml:"time,attr"`
+			Rates []struct {
+				Currency string `xml:"currency,attr"`
+				Rate     string `xml:"rate,attr"`
+			} `xml:"Cube"`
+		} `xml:"Cube"`
+	} `xml:"Cube"`
+}
+
+// ParseECBXML reads an ECB reference rate XML file (eurofxref-daily.xml or eurofxref-hist.xml)
+func ParseECBXML(r io.Reader) ([]entities.ExchangeRate, error) {
+	var envelope ecbEnvelope
+	if err := xml.NewDecoder(r).Decode(&envelope); err != nil {
+		return nil, fmt.Errorf("invalid ECB xml: %w", err)
+	}
+
+	var rates []entities.ExchangeRate
+	for _, day := range envelope.Cube.Days {
+		date, err := parseECBDate(day.Time)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ECB xml: %w", err)
+		}
+		for _, entry := range day.Rates {
+			rate, err := strconv.ParseFloat(strings.TrimSpace(entry.Rate), 64)
+			if err != nil || rate <= 0 {
+				return nil, fmt.Errorf("invalid ECB xml: bad rate %q for %s", entry.Rate, entry.Currency)
+			}
+			rates = append(rates, entities.ExchangeRate{
+				Base:   entities.ECBBaseCurrency,
+				Quote:  strings.ToUpper(strings.TrimSpace(entry.Currency)),
+				Date:   date,
+				Rate:   rate,
+				Source: "ecb-xml",
+			})
+		}
+	}
+
+	if len(rates) == 0 {
+		return nil, errors.New("invalid ECB xml: no rates found")
+	}
+	return rates, nil
+}
+
+func parseECBDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range ecbDateLayouts {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("bad date %q", value)
+}
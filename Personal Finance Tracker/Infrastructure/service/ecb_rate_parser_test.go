@@ -0,0 +1,89 @@
+package services
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"personal-finance-tracker/domain/entities"
+)
+
+func TestParseECBFiles(t *testing.T) {
+	june4 := time.Date(2024, 6, 4, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		fixture   string
+		parse     func(io.Reader) ([]entities.ExchangeRate, error)
+		wantCount int
+		wantDays  int
+		source    string
+	}{
+		{"eurofxref.csv", ParseECBCSV, 5, 1, "ecb-csv"},
+		{"eurofxref-hist.csv", ParseECBCSV, 10, 3, "ecb-csv"},
+		{"eurofxref-daily.xml", ParseECBXML, 3, 1, "ecb-xml"},
+		{"eurofxref-hist.xml", ParseECBXML, 4, 2, "ecb-xml"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			file, err := os.Open(filepath.Join("testdata", tt.fixture))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer file.Close()
+
+			rates, err := tt.parse(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(rates) != tt.wantCount {
+				t.Fatalf("parsed %d rates, want %d: %+v", len(rates), tt.wantCount, rates)
+			}
+
+			days := map[time.Time]bool{}
+			var usd *entities.ExchangeRate
+			for i, rate := range rates {
+				days[rate.Date] = true
+				if rate.Base != entities.ECBBaseCurrency || rate.Source != tt.source {
+					t.Errorf("rate %+v has the wrong base or source", rate)
+				}
+				if rate.Quote == "USD" && rate.Date.Equal(june4) {
+					usd = &rates[i]
+				}
+			}
+			if len(days) != tt.wantDays {
+				t.Errorf("rates span %d days, want %d", len(days), tt.wantDays)
+			}
+			if usd == nil || usd.Rate != 1.0876 {
+				t.Errorf("USD on 2024-06-04 = %+v, want 1.0876", usd)
+			}
+		})
+	}
+}
+
+func TestParseECBErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		parse func(io.Reader) ([]entities.ExchangeRate, error)
+		input string
+	}{
+		{"csv without a date column", ParseECBCSV, "Currency, USD\n2024-06-04, 1.0876\n"},
+		{"csv with a bad date", ParseECBCSV, "Date, USD\n4/6/2024, 1.0876\n"},
+		{"csv with a bad rate", ParseECBCSV, "Date, USD\n2024-06-04, one\n"},
+		{"csv with a negative rate", ParseECBCSV, "Date, USD\n2024-06-04, -1.0876\n"},
+		{"empty csv", ParseECBCSV, ""},
+		{"xml that is not xml", ParseECBXML, "Date, USD"},
+		{"xml without rates", ParseECBXML, "<Envelope><Cube></Cube></Envelope>"},
+		{"xml with a bad rate", ParseECBXML, `<Envelope><Cube><Cube time="2024-06-04"><Cube currency="USD" rate="x"/></Cube></Cube></Envelope>`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.parse(strings.NewReader(tt.input)); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
@@ -1,10 +1,16 @@
 package services
 
 import (
+    "bytes"
+    "encoding/base64"
     "fmt"
+    "mime"
+    "mime/multipart"
     "net/smtp"
+    "net/textproto"
     "os"
     "strings"
+    "time"
 )
 
 // EmailService handles email operations
@@ -112,13 +118,142 @@ Your Application Team
     return nil
 }
 
+// SendBillReminder reminds a user that a bill is due soon
+func (e *EmailService) SendBillReminder(email, fullName, payee string, dueDate time.Time, amountMin, amountMax float64, currency string) error {
+    subject := fmt.Sprintf("Reminder: %s is due on %s", payee, dueDate.Format("Jan 2, 2006"))
+
+    amount := fmt.Sprintf("%.2f %s", amountMax, currency)
+    if amountMin != amountMax {
+        amount = fmt.Sprintf("%.2f - %.2f %s", amountMin, amountMax, currency)
+    }
+
+    body := fmt.Sprintf(`
+Hello %s,
+
+Your bill from %s is due on %s.
+
+Expected amount: %s
+
+Best regards,
+Your Application Team
+`, fullName, payee, dueDate.Format("Monday, January 2, 2006"), strings.TrimSpace(amount))
+
+    // Send real email if SMTP is configured. A failure is returned so the
+    // reminder is not recorded as sent and is tried again on the next run.
+    if e.smtpHost != "" && e.smtpUsername != "" && e.smtpPassword != "" {
+        if err := e.sendEmail(email, subject, body); err != nil {
+            return err
+        }
+        fmt.Printf("✅ Bill reminder sent successfully to: %s\n", email)
+        return nil
+    }
+
+    // Fallback to console logging
+    fmt.Printf("=== BILL REMINDER (CONSOLE LOG) ===\n")
+    fmt.Printf("To: %s\n", email)
+    fmt.Printf("Subject: %s\n", subject)
+    fmt.Printf("Body:\n%s\n", body)
+    fmt.Printf("===================================\n")
+
+    return nil
+}
+
+// SendHouseholdInvitation invites someone to join a shared household
+func (e *EmailService) SendHouseholdInvitation(email, inviterName, householdName, role, inviteToken string) error {
+    baseURL := os.Getenv("APP_BASE_URL")
+    if baseURL == "" {
+        baseURL = "http://localhost:8080"
+    }
+
+    acceptLink := fmt.Sprintf("%s/households/invitations/accept?token=%s", baseURL, inviteToken)
+
+    subject := fmt.Sprintf("%s invited you to %s", inviterName, householdName)
+
+    body := fmt.Sprintf(`
+Hello,
+
+%s has invited you to join the household "%s" as %s.
+
+Open this link to see the invitation, then sign in with this email address to accept it:
+
+%s
+
+This invitation will expire in 7 days. If you don't know the sender, you can ignore this email.
+
+Best regards,
+Your Application Team
+`, inviterName, householdName, role, acceptLink)
+
+    // Try to send real email if SMTP is configured
+    if e.smtpHost != "" && e.smtpUsername != "" && e.smtpPassword != "" {
+        err := e.sendEmail(email, subject, body)
+        if err == nil {
+            fmt.Printf("✅ Household invitation sent successfully to: %s\n", email)
+            return nil
+        }
+        fmt.Printf("⚠️ Failed to send email via SMTP: %v\n", err)
+    }
+
+    // Fallback to console logging
+    fmt.Printf("=== HOUSEHOLD INVITATION (CONSOLE LOG) ===\n")
+    fmt.Printf("To: %s\n", email)
+    fmt.Printf("Subject: %s\n", subject)
+    fmt.Printf("Body:\n%s\n", body)
+    fmt.Printf("==========================================\n")
+
+    return nil
+}
+
+// SendInvoice emails an invoice to the client with the PDF attached
+func (e *EmailService) SendInvoice(email, clientName, senderName, number string, amountDue float64, currency string, dueDate time.Time, pdf []byte) error {
+    subject := fmt.Sprintf("Invoice %s from %s", number, senderName)
+
+    body := fmt.Sprintf(`
+Hello %s,
+
+Please find attached invoice %s from %s.
+
+Amount due: %.2f %s
+Due date: %s
+
+Please include the invoice number %s with your payment.
+
+Best regards,
+%s
+`, clientName, number, senderName, amountDue, currency, dueDate.Format("Monday, January 2, 2006"), number, senderName)
+
+    filename := fmt.Sprintf("invoice-%s.pdf", number)
+
+    // Send real email if SMTP is configured. Unlike other mail, a failure is
+    // returned, so no sent_at is recorded for an invoice that wasn't delivered.
+    if e.smtpHost != "" && e.smtpUsername != "" && e.smtpPassword != "" {
+        if err := e.sendEmailWithAttachment(email, subject, body, filename, "application/pdf", pdf); err != nil {
+            return err
+        }
+        fmt.Printf("✅ Invoice %s sent successfully to: %s\n", number, email)
+        return nil
+    }
+
+    // Fallback to console logging
+    fmt.Printf("=== INVOICE (CONSOLE LOG) ===\n")
+    fmt.Printf("To: %s\n", email)
+    fmt.Printf("Subject: %s\n", subject)
+    fmt.Printf("Body:\n%s\n", body)
+    fmt.Printf("Attachment: %s (%d bytes)\n", filename, len(pdf))
+    fmt.Printf("=============================\n")
+
+    return nil
+}
+
 // sendEmail sends an email using SMTP
 func (e *EmailService) sendEmail(to, subject, body string) error {
     // Email headers
     headers := make(map[string]string)
     headers["From"] = e.smtpUsername
     headers["To"] = to
-    headers["Subject"] = subject
+    // Subjects hold user-chosen names; encoding them keeps a line break in
+    // one from starting a new header
+    headers["Subject"] = mime.QEncoding.Encode("utf-8", subject)
     headers["MIME-Version"] = "1.0"
     headers["Content-Type"] = "text/plain; charset=UTF-8"
 
@@ -140,3 +275,51 @@ func (e *EmailService) sendEmail(to, subject, body string) error {
     return err
 }
 
+
+// sendEmailWithAttachment sends a plain text email with one attached file
+func (e *EmailService) sendEmailWithAttachment(to, subject, body, filename, contentType string, data []byte) error {
+    var buffer bytes.Buffer
+    writer := multipart.NewWriter(&buffer)
+
+    // Email headers
+    var message strings.Builder
+    message.WriteString(fmt.Sprintf("From: %s\r\n", e.smtpUsername))
+    message.WriteString(fmt.Sprintf("To: %s\r\n", to))
+    message.WriteString(fmt.Sprintf("Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject)))
+    message.WriteString("MIME-Version: 1.0\r\n")
+    message.WriteString(fmt.Sprintf("Content-Type: multipart/mixed; boundary=%s\r\n\r\n", writer.Boundary()))
+
+    textPart, err := writer.CreatePart(textproto.MIMEHeader{"Content-Type": {"text/plain; charset=UTF-8"}})
+    if err != nil {
+        return err
+    }
+    textPart.Write([]byte(body))
+
+    filePart, err := writer.CreatePart(textproto.MIMEHeader{
+        "Content-Type":              {contentType},
+        "Content-Transfer-Encoding": {"base64"},
+        "Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": filename})},
+    })
+    if err != nil {
+        return err
+    }
+    // Base64 lines may not exceed 76 characters
+    encoded := base64.StdEncoding.EncodeToString(data)
+    for len(encoded) > 76 {
+        filePart.Write([]byte(encoded[:76] + "\r\n"))
+        encoded = encoded[76:]
+    }
+    filePart.Write([]byte(encoded + "\r\n"))
+
+    if err := writer.Close(); err != nil {
+        return err
+    }
+    message.Write(buffer.Bytes())
+
+    // SMTP authentication
+    auth := smtp.PlainAuth("", e.smtpUsername, e.smtpPassword, e.smtpHost)
+
+    // Send email
+    addr := fmt.Sprintf("%s:%s", e.smtpHost, e.smtpPort)
+    return smtp.SendMail(addr, auth, e.smtpUsername, []string{to}, []byte(message.String()))
+}
@@ -0,0 +1,73 @@
+package services
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"personal-finance-tracker/domain/entities"
+)
+
+// icsLineLimit is the longest content line RFC 5545 allows, in octets
+const icsLineLimit = 75
+
+var icsEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+// WriteICS renders the events as an iCalendar (RFC 5545) document of
+// all-day VEVENTs, each with a display VALARM when it asks for one
+func WriteICS(w io.Writer, calendarName string, events []entities.CalendarEvent, now time.Time) error {
+	out := bufio.NewWriter(w)
+	stamp := now.UTC().Format("20060102T150405Z")
+
+	writeICSLine(out, "BEGIN:VCALENDAR")
+	writeICSLine(out, "VERSION:2.0")
+	writeICSLine(out, "PRODID:-//Personal Finance Tracker//Calendar Feed//EN")
+	writeICSLine(out, "CALSCALE:GREGORIAN")
+	writeICSLine(out, "METHOD:PUBLISH")
+	writeICSLine(out, "X-WR-CALNAME:"+icsEscaper.Replace(calendarName))
+
+	for _, event := range events {
+		writeICSLine(out, "BEGIN:VEVENT")
+		writeICSLine(out, "UID:"+event.UID)
+		writeICSLine(out, "DTSTAMP:"+stamp)
+		writeICSLine(out, "DTSTART;VALUE=DATE:"+event.Date.Format("20060102"))
+		writeICSLine(out, "DTEND;VALUE=DATE:"+event.Date.AddDate(0, 0, 1).Format("20060102"))
+		writeICSLine(out, "SUMMARY:"+icsEscaper.Replace(event.Summary))
+		if event.Description != "" {
+			writeICSLine(out, "DESCRIPTION:"+icsEscaper.Replace(event.Description))
+		}
+		writeICSLine(out, "TRANSP:TRANSPARENT")
+		if event.AlarmDaysBefore > 0 {
+			writeICSLine(out, "BEGIN:VALARM")
+			writeICSLine(out, "ACTION:DISPLAY")
+			writeICSLine(out, "DESCRIPTION:"+icsEscaper.Replace(event.Summary))
+			writeICSLine(out, fmt.Sprintf("TRIGGER:-P%dD", event.AlarmDaysBefore))
+			writeICSLine(out, "END:VALARM")
+		}
+		writeICSLine(out, "END:VEVENT")
+	}
+
+	writeICSLine(out, "END:VCALENDAR")
+	return out.Flush()
+}
+
+// writeICSLine ends the line with CRLF and folds it at the octet limit
+// without splitting a UTF-8 character
+func writeICSLine(out *bufio.Writer, line string) {
+	limit := icsLineLimit
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		out.WriteString(line[:cut])
+		out.WriteString("\r\n ")
+		line = line[cut:]
+		// Continuation lines start with a space, which counts against the limit
+		limit = icsLineLimit - 1
+	}
+	out.WriteString(line)
+	out.WriteString("\r\n")
+}
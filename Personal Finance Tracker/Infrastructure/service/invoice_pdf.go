@@ -0,0 +1,152 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"personal-finance-tracker/domain/entities"
+)
+
+// Right edges of the line item columns; the description column is wrapped
+// to fit left of the quantity
+const (
+	invoiceDescriptionChars = 36
+	invoiceQuantityRight    = pdfMargin + 276
+	invoicePriceRight       = pdfMargin + 354
+	invoiceTaxRight         = pdfMargin + 396
+	invoiceAmountRight      = pdfPageWidth - pdfMargin
+)
+
+const (
+	invoiceFontSize = 10.0
+	invoiceLeading  = 14.0
+)
+
+// RenderInvoicePDF lays out the invoice as a single- or multi-page A4 PDF,
+// with the seller's name and email as the sender
+func RenderInvoicePDF(invoice *entities.Invoice, sellerName, sellerEmail string) []byte {
+	d := newPDFDocument()
+	right := pdfPageWidth - pdfMargin
+
+	d.advance(20)
+	d.text(pdfMargin, 20, true, "INVOICE")
+	number := invoice.Number
+	if number == "" {
+		number = "DRAFT"
+	}
+	d.textRight(right, 12, true, number)
+
+	// Sender on the left, dates on the right
+	d.advance(30)
+	d.text(pdfMargin, invoiceFontSize, true, sellerName)
+	d.textRight(right, invoiceFontSize, false, "Issue date: "+invoice.IssueDate.Format("2006-01-02"))
+	d.advance(invoiceLeading)
+	d.text(pdfMargin, invoiceFontSize, false, sellerEmail)
+	d.textRight(right, invoiceFontSize, false, "Due date: "+invoice.DueDate.Format("2006-01-02"))
+
+	d.advance(invoiceLeading * 2)
+	d.text(pdfMargin, invoiceFontSize, true, "Bill to:")
+	d.advance(invoiceLeading)
+	d.text(pdfMargin, invoiceFontSize, false, invoice.Client.Name)
+	for _, line := range wrapText(invoice.Client.Address, 60) {
+		if line == "" {
+			continue
+		}
+		d.advance(invoiceLeading)
+		d.text(pdfMargin, invoiceFontSize, false, line)
+	}
+	if invoice.Client.Email != "" {
+		d.advance(invoiceLeading)
+		d.text(pdfMargin, invoiceFontSize, false, invoice.Client.Email)
+	}
+
+	d.advance(invoiceLeading * 2)
+	writeInvoiceItemHeader(d)
+	d.pageHeader = func() {
+		d.advance(invoiceLeading)
+		writeInvoiceItemHeader(d)
+	}
+	for _, item := range invoice.Items {
+		lines := wrapText(item.Description, invoiceDescriptionChars)
+		d.advance(invoiceLeading)
+		d.text(pdfMargin, invoiceFontSize, false, lines[0])
+		d.textRight(invoiceQuantityRight, invoiceFontSize, false, formatQuantity(item.Quantity))
+		d.textRight(invoicePriceRight, invoiceFontSize, false, formatUnitPrice(item.UnitPrice))
+		d.textRight(invoiceTaxRight, invoiceFontSize, false, formatQuantity(item.TaxRate)+"%")
+		d.textRight(invoiceAmountRight, invoiceFontSize, false, formatMoney(item.Net))
+		for _, line := range lines[1:] {
+			d.advance(invoiceLeading)
+			d.text(pdfMargin, invoiceFontSize, false, line)
+		}
+	}
+	d.pageHeader = nil
+	d.rule(pdfMargin, right)
+
+	// Totals, labels right-aligned against the amount column
+	labelRight := invoiceTaxRight
+	total := func(label, amount string, bold bool) {
+		d.advance(invoiceLeading)
+		d.textRight(labelRight, invoiceFontSize, bold, label)
+		d.textRight(invoiceAmountRight, invoiceFontSize, bold, amount)
+	}
+	d.advance(invoiceLeading / 2)
+	total("Subtotal", formatMoney(invoice.Subtotal), false)
+	for _, line := range invoice.TaxLines {
+		total(fmt.Sprintf("Tax %s%% on %s", formatQuantity(line.Rate), formatMoney(line.Net)), formatMoney(line.Tax), false)
+	}
+	total("Total "+invoice.Currency, formatMoney(invoice.Total), true)
+	if invoice.AmountPaid > 0 {
+		total("Paid", formatMoney(invoice.AmountPaid), false)
+		total("Amount due "+invoice.Currency, formatMoney(invoice.AmountDue), true)
+	}
+
+	if strings.TrimSpace(invoice.Notes) != "" {
+		d.advance(invoiceLeading * 2)
+		d.text(pdfMargin, invoiceFontSize, true, "Notes")
+		for _, line := range wrapText(invoice.Notes, 80) {
+			d.advance(invoiceLeading)
+			d.text(pdfMargin, invoiceFontSize, false, line)
+		}
+	}
+
+	return d.bytes()
+}
+
+func writeInvoiceItemHeader(d *pdfDocument) {
+	d.text(pdfMargin, invoiceFontSize, true, "Description")
+	d.textRight(invoiceQuantityRight, invoiceFontSize, true, "Qty")
+	d.textRight(invoicePriceRight, invoiceFontSize, true, "Unit price")
+	d.textRight(invoiceTaxRight, invoiceFontSize, true, "Tax")
+	d.textRight(invoiceAmountRight, invoiceFontSize, true, "Amount")
+	d.rule(pdfMargin, pdfPageWidth-pdfMargin)
+	d.advance(invoiceLeading / 2)
+}
+
+// formatMoney prints an amount with two decimals and thousands separators
+func formatMoney(amount float64) string {
+	s := strconv.FormatFloat(amount, 'f', 2, 64)
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	whole, cents := s[:len(s)-3], s[len(s)-3:]
+	for i := len(whole) - 3; i > 0; i -= 3 {
+		whole = whole[:i] + "," + whole[i:]
+	}
+	return sign + whole + cents
+}
+
+// formatUnitPrice keeps sub-cent unit prices exact, so quantity times price
+// visibly adds up to the line amount
+func formatUnitPrice(price float64) string {
+	if math.Abs(price*100-math.Round(price*100)) > 1e-9 {
+		return formatQuantity(price)
+	}
+	return formatMoney(price)
+}
+
+func formatQuantity(quantity float64) string {
+	return strconv.FormatFloat(quantity, 'f', -1, 64)
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	"personal-finance-tracker/domain/entities"
+)
+
+func TestRenderInvoicePDFRepeatsItemHeader(t *testing.T) {
+	tests := []struct {
+		items     int
+		wantPages int
+	}{
+		{items: 5, wantPages: 1},
+		{items: 60, wantPages: 2},
+		{items: 120, wantPages: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%d items", tt.items), func(t *testing.T) {
+			invoice := &entities.Invoice{
+				Number:    "INV-0001",
+				Client:    entities.InvoiceClient{Name: "Acme Ltd", Address: "1 Main St"},
+				Currency:  "USD",
+				IssueDate: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+				DueDate:   time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC),
+			}
+			for i := 0; i < tt.items; i++ {
+				invoice.Items = append(invoice.Items, entities.InvoiceLineItem{
+					Description: fmt.Sprintf("Consulting, week %d", i+1),
+					Quantity:    10,
+					UnitPrice:   95,
+					Net:         950,
+				})
+			}
+
+			pdf := RenderInvoicePDF(invoice, "Jo Smith", "jo@example.com")
+			pages := bytes.Count(pdf, []byte("/Type /Page /Parent"))
+			if pages != tt.wantPages {
+				t.Fatalf("pages = %d, want %d", pages, tt.wantPages)
+			}
+			if headers := bytes.Count(pdf, []byte("(Description) Tj")); headers != pages {
+				t.Errorf("item header drawn %d times on %d pages", headers, pages)
+			}
+		})
+	}
+}
@@ -43,9 +43,11 @@ func (s *JWTService) GenerateTokens(userID string, userRole string) (string, str
     }
 
     // Refresh Token (7 day expiry)
+    // The type claim keeps it from being accepted as an access token
     refreshClaims := jwt.MapClaims{
         "sub": userID,
         "role": userRole,
+        "type": "refresh",
         "iat": now.Unix(),                          // timestamp 
         "exp": now.Add(7 * 24 * time.Hour).Unix(),
         "jti": uuid.NewString(),
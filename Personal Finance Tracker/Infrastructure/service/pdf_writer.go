@@ -0,0 +1,170 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// A4 in points, with the same margin on every side
+const (
+	pdfPageWidth  = 595.28
+	pdfPageHeight = 841.89
+	pdfMargin     = 50.0
+)
+
+// pdfCharWidth is the advance of every Courier glyph, per point of font size
+const pdfCharWidth = 0.6
+
+// pdfDocument lays out lines of text top to bottom on A4 pages, starting a
+// new page when one fills up. It uses the standard Courier fonts, which
+// every reader has and whose fixed width makes columns easy to align.
+type pdfDocument struct {
+	pages []*bytes.Buffer
+	y     float64
+	// pageHeader, when set, is drawn at the top of each new page, such as
+	// the column titles of a table that runs over
+	pageHeader func()
+}
+
+func newPDFDocument() *pdfDocument {
+	d := &pdfDocument{}
+	d.newPage()
+	return d
+}
+
+func (d *pdfDocument) newPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+	d.y = pdfPageHeight - pdfMargin
+}
+
+// advance moves down by height, breaking the page when the line wouldn't fit
+func (d *pdfDocument) advance(height float64) {
+	if d.y-height < pdfMargin {
+		d.newPage()
+		if header := d.pageHeader; header != nil {
+			d.pageHeader = nil
+			header()
+			d.pageHeader = header
+		}
+	}
+	d.y -= height
+}
+
+// text draws s with its left edge at x on the current line
+func (d *pdfDocument) text(x, size float64, bold bool, s string) {
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+	fmt.Fprintf(d.pages[len(d.pages)-1], "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, d.y, pdfString(s))
+}
+
+// textRight draws s with its right edge at x on the current line
+func (d *pdfDocument) textRight(x, size float64, bold bool, s string) {
+	d.text(x-pdfTextWidth(s, size), size, bold, s)
+}
+
+// rule draws a horizontal line a little below the current line
+func (d *pdfDocument) rule(from, to float64) {
+	fmt.Fprintf(d.pages[len(d.pages)-1], "0.5 w %.2f %.2f m %.2f %.2f l S\n", from, d.y-4, to, d.y-4)
+}
+
+// bytes assembles the PDF file
+func (d *pdfDocument) bytes() []byte {
+	var out bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// Objects 1-4 are the catalog, page tree and fonts; each page is then a
+	// page object followed by its content stream
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+2*i)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Courier /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Courier-Bold /Encoding /WinAnsiEncoding >>")
+	for i, page := range d.pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>", pdfPageWidth, pdfPageHeight, 6+2*i))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.Len(), page.String()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return out.Bytes()
+}
+
+func pdfTextWidth(s string, size float64) float64 {
+	return float64(len([]rune(s))) * pdfCharWidth * size
+}
+
+// winAnsiExtras are the WinAnsi characters outside Latin-1, by their code
+var winAnsiExtras = map[rune]byte{
+	'€': 0x80, '‚': 0x82, '„': 0x84, '…': 0x85, '‘': 0x91, '’': 0x92,
+	'“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97, '™': 0x99,
+}
+
+// pdfString encodes s as the body of a PDF literal string in WinAnsi.
+// Characters the encoding lacks become "?".
+func pdfString(s string) string {
+	var out strings.Builder
+	for _, r := range s {
+		code, extra := winAnsiExtras[r]
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			out.WriteByte('\\')
+			out.WriteRune(r)
+		case extra:
+			fmt.Fprintf(&out, `\%03o`, code)
+		case r >= 0x20 && r < 0x7f:
+			out.WriteRune(r)
+		case r >= 0xa0 && r <= 0xff:
+			fmt.Fprintf(&out, `\%03o`, r)
+		default:
+			out.WriteByte('?')
+		}
+	}
+	return out.String()
+}
+
+// wrapText breaks s into lines of at most width characters, at spaces where
+// possible. Existing line breaks are kept.
+func wrapText(s string, width int) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n") {
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			for len([]rune(word)) > width {
+				if line != "" {
+					lines = append(lines, line)
+					line = ""
+				}
+				runes := []rune(word)
+				lines = append(lines, string(runes[:width]))
+				word = string(runes[width:])
+			}
+			switch {
+			case line == "":
+				line = word
+			case len([]rune(line))+1+len([]rune(word)) <= width:
+				line += " " + word
+			default:
+				lines = append(lines, line)
+				line = word
+			}
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
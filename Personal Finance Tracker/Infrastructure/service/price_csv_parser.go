@@ -0,0 +1,93 @@
+package services
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"personal-finance-tracker/domain/entities"
+)
+
+// ParsePriceCSV reads a closing price history. Columns are matched by
+// header name: date and close (or price) are required, symbol is optional
+// and defaultSymbol is used when the file has none, as in Yahoo-style
+// Date,Open,High,Low,Close,Adj Close,Volume exports.
+func ParsePriceCSV(r io.Reader, defaultSymbol string) ([]entities.SecurityPrice, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, errors.New("invalid price csv: missing header")
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	dateColumn, ok := columns["date"]
+	if !ok {
+		return nil, errors.New("invalid price csv: missing date column")
+	}
+	closeColumn, ok := columns["close"]
+	if !ok {
+		closeColumn, ok = columns["price"]
+	}
+	if !ok {
+		return nil, errors.New("invalid price csv: missing close column")
+	}
+	symbolColumn, hasSymbol := columns["symbol"]
+	defaultSymbol = strings.ToUpper(strings.TrimSpace(defaultSymbol))
+	if !hasSymbol && defaultSymbol == "" {
+		return nil, errors.New("invalid price csv: no symbol column, a symbol must be given")
+	}
+
+	var prices []entities.SecurityPrice
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid price csv at line %d: %w", line, err)
+		}
+		if len(record) <= dateColumn || len(record) <= closeColumn {
+			continue
+		}
+
+		value := strings.TrimSpace(record[closeColumn])
+		// Exports mark days without trading as null
+		if value == "" || strings.EqualFold(value, "null") {
+			continue
+		}
+		closePrice, err := strconv.ParseFloat(value, 64)
+		if err != nil || closePrice <= 0 {
+			return nil, fmt.Errorf("invalid price csv at line %d: bad close %q", line, value)
+		}
+		date, err := time.Parse("2006-01-02", strings.TrimSpace(record[dateColumn]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid price csv at line %d: bad date %q", line, record[dateColumn])
+		}
+
+		symbol := defaultSymbol
+		if hasSymbol && len(record) > symbolColumn && strings.TrimSpace(record[symbolColumn]) != "" {
+			symbol = strings.ToUpper(strings.TrimSpace(record[symbolColumn]))
+		}
+		if symbol == "" {
+			return nil, fmt.Errorf("invalid price csv at line %d: missing symbol", line)
+		}
+
+		prices = append(prices, entities.SecurityPrice{
+			Symbol: symbol,
+			Date:   date,
+			Close:  closePrice,
+		})
+	}
+
+	return prices, nil
+}
@@ -0,0 +1,331 @@
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"html"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"personal-finance-tracker/domain/entities"
+)
+
+// maxMIMEDepth stops parsing pathologically nested multiparts
+const maxMIMEDepth = 10
+
+// Total lines in order of preference; plain "total" is the last resort and
+// never matches "subtotal"
+var receiptTotalRegexes = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)\b(grand total|order total|total charged|amount charged|amount paid|total paid|payment total)\b`),
+	regexp.MustCompile(`(?i)\btotal\b`),
+}
+
+var (
+	receiptAmountRegex = regexp.MustCompile(`(?i)(US\$|[$€£]|USD|EUR|GBP)?\s*(\d{1,3}(?:,\d{3})+(?:\.\d{2})?|\d{1,3}(?:\.\d{3})+(?:,\d{2})?|\d+[.,]\d{2})\s*(USD|EUR|GBP|€)?`)
+	htmlTagRegex       = regexp.MustCompile(`(?s)<(script|style)\b.*?</(script|style)>|<[^>]+>`)
+	htmlBreakRegex     = regexp.MustCompile(`(?i)<(br|/p|/div|/tr|/li|/h[1-6])\b[^>]*>`)
+	// Header lines that forwarding clients put above the original message
+	forwardedFromRegex = regexp.MustCompile(`(?im)^\s*>?\s*\*?From:\*?\s*(.+)$`)
+	forwardedDateRegex = regexp.MustCompile(`(?im)^\s*>?\s*\*?(Date|Sent):\*?\s*(.+)$`)
+)
+
+// Layouts of the date line mail clients write above a forwarded message,
+// tried after RFC 5322: Gmail's "Mon, Jun 3, 2024 at 10:12 AM", Outlook's
+// "Monday, June 3, 2024 10:12 AM" and Apple Mail's "June 3, 2024 at
+// 10:12:00 AM EDT"
+var forwardedDateLayouts = []string{
+	"Mon, Jan 2, 2006 at 3:04 PM",
+	"Mon, 2 Jan 2006 at 15:04",
+	"Monday, January 2, 2006 3:04 PM",
+	"Monday, January 2, 2006 at 3:04 PM",
+	"Monday, 2 January 2006 15:04",
+	"January 2, 2006 at 3:04:05 PM MST",
+	"January 2, 2006 at 3:04 PM",
+}
+
+var receiptCurrencySymbols = map[string]string{"$": "USD", "US$": "USD", "€": "EUR", "£": "GBP"}
+
+// ParseReceiptEmail reads a receipt email, usually forwarded by the user,
+// and picks out the merchant, total, currency and date. When the body
+// quotes a forwarded message its sender and date win over the outer
+// headers, which belong to the user who forwarded it.
+func ParseReceiptEmail(r io.Reader) (*entities.ParsedReceipt, error) {
+	message, err := mail.ReadMessage(r)
+	if err != nil {
+		return nil, errors.New("invalid email: " + err.Error())
+	}
+
+	decoder := new(mime.WordDecoder)
+	subject, err := decoder.DecodeHeader(message.Header.Get("Subject"))
+	if err != nil {
+		subject = message.Header.Get("Subject")
+	}
+	from, err := decoder.DecodeHeader(message.Header.Get("From"))
+	if err != nil {
+		from = message.Header.Get("From")
+	}
+	receipt := &entities.ParsedReceipt{
+		Subject:     subject,
+		From:        from,
+		Attachments: []entities.ReceiptAttachment{},
+	}
+
+	var plain, htmlText strings.Builder
+	if err := walkMIMEPart(textproto.MIMEHeader(message.Header), message.Body, 0, receipt, &plain, &htmlText); err != nil {
+		return nil, err
+	}
+	// The plain text is read first, the HTML alternative only for what it
+	// lacks, as when a plain note forwards an HTML-only receipt
+	texts := []string{plain.String(), htmlText.String()}
+
+	sender := message.Header.Get("From")
+	for _, text := range texts {
+		if match := forwardedFromRegex.FindStringSubmatch(text); match != nil {
+			sender = strings.TrimSpace(match[1])
+			break
+		}
+	}
+	receipt.Merchant = merchantName(sender, decoder)
+
+	// Forwarding clients write the original date in the forwarder's time
+	// zone and mostly without one, so the outer Date header supplies it
+	outerDate, outerErr := message.Header.Date()
+	location := time.UTC
+	if outerErr == nil {
+		location = outerDate.Location()
+	}
+	for _, text := range texts {
+		for _, match := range forwardedDateRegex.FindAllStringSubmatch(text, -1) {
+			if date, ok := parseForwardedDate(match[2], location); ok {
+				receipt.Date = &date
+				break
+			}
+		}
+		if receipt.Date != nil {
+			break
+		}
+	}
+	if receipt.Date == nil && outerErr == nil {
+		receipt.Date = &outerDate
+	}
+
+	for _, text := range texts {
+		if receipt.Amount, receipt.Currency = findReceiptTotal(text); receipt.Amount != nil {
+			break
+		}
+	}
+	return receipt, nil
+}
+
+func parseForwardedDate(value string, location *time.Location) (time.Time, bool) {
+	// Newer clients put narrow no-break spaces before AM and PM
+	value = strings.Join(strings.FieldsFunc(value, func(r rune) bool {
+		return r == ' ' || r == '\t' || r == '\u00a0' || r == '\u202f'
+	}), " ")
+	if date, err := mail.ParseDate(value); err == nil {
+		return date, true
+	}
+	for _, layout := range forwardedDateLayouts {
+		if date, err := time.ParseInLocation(layout, value, location); err == nil {
+			return date, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// walkMIMEPart collects text bodies and lists attachments, descending into
+// multipart containers and attached messages
+func walkMIMEPart(header textproto.MIMEHeader, body io.Reader, depth int, receipt *entities.ParsedReceipt, plain, htmlText *strings.Builder) error {
+	if depth > maxMIMEDepth {
+		return errors.New("invalid email: too deeply nested")
+	}
+
+	mediaType, params, err := mime.ParseMediaType(header.Get("Content-Type"))
+	if err != nil {
+		mediaType = "text/plain"
+	}
+	disposition, dispositionParams, _ := mime.ParseMediaType(header.Get("Content-Disposition"))
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		reader := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := reader.NextRawPart()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return errors.New("invalid email: " + err.Error())
+			}
+			if err := walkMIMEPart(part.Header, part, depth+1, receipt, plain, htmlText); err != nil {
+				return err
+			}
+		}
+	}
+
+	content, err := io.ReadAll(decodeTransferEncoding(header.Get("Content-Transfer-Encoding"), body))
+	if err != nil {
+		return errors.New("invalid email: " + err.Error())
+	}
+
+	filename := dispositionParams["filename"]
+	if filename == "" {
+		filename = params["name"]
+	}
+
+	switch {
+	case mediaType == "message/rfc822" && disposition != "attachment":
+		// Forwarded inline: the original sender and date go in as the
+		// header lines a forwarding client would write, then its own parts
+		// are walked like the outer message's
+		inner, err := mail.ReadMessage(bytes.NewReader(content))
+		if err != nil {
+			return errors.New("invalid email: " + err.Error())
+		}
+		plain.WriteString("\n")
+		for _, key := range []string{"From", "Date"} {
+			if value := inner.Header.Get(key); value != "" {
+				plain.WriteString(key + ": " + value + "\n")
+			}
+		}
+		plain.WriteString("\n")
+		return walkMIMEPart(textproto.MIMEHeader(inner.Header), inner.Body, depth+1, receipt, plain, htmlText)
+	case disposition == "attachment" || filename != "" || !strings.HasPrefix(mediaType, "text/"):
+		receipt.Attachments = append(receipt.Attachments, entities.ReceiptAttachment{
+			Filename:    filename,
+			ContentType: mediaType,
+			Size:        len(content),
+			Data:        content,
+		})
+	case mediaType == "text/html":
+		htmlText.WriteString(htmlToText(string(content)))
+		htmlText.WriteString("\n")
+	default:
+		plain.Write(content)
+		plain.WriteString("\n")
+	}
+	return nil
+}
+
+func decodeTransferEncoding(encoding string, body io.Reader) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "base64":
+		// Line breaks inside base64 bodies are not part of the data
+		return base64.NewDecoder(base64.StdEncoding, &newlineSkipper{reader: bufio.NewReader(body)})
+	case "quoted-printable":
+		return quotedprintable.NewReader(body)
+	default:
+		return body
+	}
+}
+
+type newlineSkipper struct {
+	reader *bufio.Reader
+}
+
+func (s *newlineSkipper) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		b, err := s.reader.ReadByte()
+		if err != nil {
+			return n, err
+		}
+		if b == '\r' || b == '\n' || b == ' ' || b == '\t' {
+			continue
+		}
+		p[n] = b
+		n++
+	}
+	return n, nil
+}
+
+func htmlToText(value string) string {
+	value = htmlBreakRegex.ReplaceAllString(value, "\n")
+	value = htmlTagRegex.ReplaceAllString(value, " ")
+	return html.UnescapeString(value)
+}
+
+// merchantName prefers the sender's display name, falling back to the
+// main part of the address domain ("orders@shop.example.com" is "example")
+func merchantName(sender string, decoder *mime.WordDecoder) string {
+	address, err := (&mail.AddressParser{WordDecoder: decoder}).Parse(sender)
+	if err != nil {
+		return strings.Trim(strings.TrimSpace(sender), `"`)
+	}
+	if address.Name != "" {
+		return address.Name
+	}
+	at := strings.LastIndex(address.Address, "@")
+	if at < 0 {
+		return ""
+	}
+	labels := strings.Split(address.Address[at+1:], ".")
+	if len(labels) >= 2 {
+		return labels[len(labels)-2]
+	}
+	return labels[0]
+}
+
+// findReceiptTotal returns the amount on the best total line: the most
+// specific label wins, and the last such line if it repeats
+func findReceiptTotal(text string) (*float64, string) {
+	lines := strings.Split(text, "\n")
+	for _, label := range receiptTotalRegexes {
+		for i := len(lines) - 1; i >= 0; i-- {
+			location := label.FindStringIndex(lines[i])
+			if location == nil {
+				continue
+			}
+			// The amount may sit after the label or alone on the next line
+			candidates := []string{lines[i][location[1]:]}
+			if i+1 < len(lines) {
+				candidates = append(candidates, lines[i+1])
+			}
+			for _, candidate := range candidates {
+				if amount, currency, ok := parseReceiptAmount(candidate); ok {
+					return &amount, currency
+				}
+			}
+		}
+	}
+	return nil, ""
+}
+
+func parseReceiptAmount(value string) (float64, string, bool) {
+	match := receiptAmountRegex.FindStringSubmatch(value)
+	if match == nil {
+		return 0, "", false
+	}
+
+	// Only two digits after the last separator make it the decimal point,
+	// as in 1,234.56 and 1.234,56; "1,234" and "1.234" have no cents
+	number := match[2]
+	separators := strings.NewReplacer(",", "", ".", "")
+	if last := strings.LastIndexAny(number, ",."); last >= 0 && len(number)-last-1 == 2 {
+		number = separators.Replace(number[:last]) + "." + number[last+1:]
+	} else {
+		number = separators.Replace(number)
+	}
+	amount, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, "", false
+	}
+
+	currency := strings.ToUpper(match[1])
+	if currency == "" {
+		currency = strings.ToUpper(match[3])
+	}
+	if code, ok := receiptCurrencySymbols[currency]; ok {
+		currency = code
+	}
+	return amount, currency, true
+}
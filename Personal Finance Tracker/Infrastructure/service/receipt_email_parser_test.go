@@ -0,0 +1,163 @@
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"personal-finance-tracker/domain/entities"
+)
+
+func TestParseReceiptEmail(t *testing.T) {
+	tests := []struct {
+		fixture     string
+		merchant    string
+		amount      float64
+		currency    string
+		date        string
+		attachments []entities.ReceiptAttachment
+	}{
+		{
+			fixture:  "gmail_forward.eml",
+			merchant: "Blue Bottle Coffee",
+			amount:   13.08,
+			currency: "USD",
+			date:     "2024-06-03T10:12:00-04:00",
+		},
+		{
+			fixture:  "outlook_forward.eml",
+			merchant: "Contoso Store",
+			amount:   45.10,
+			currency: "USD",
+			date:     "2024-06-03T10:12:00+01:00",
+		},
+		{
+			fixture:  "html_only.eml",
+			merchant: "Shop",
+			amount:   23.50,
+			currency: "USD",
+			date:     "2024-06-01T12:00:00Z",
+		},
+		{
+			fixture:     "base64.eml",
+			merchant:    "Lumen Electric",
+			amount:      1284.37,
+			currency:    "USD",
+			date:        "2024-06-10T08:00:00-07:00",
+			attachments: []entities.ReceiptAttachment{{Filename: "statement.pdf", ContentType: "application/pdf", Size: 36}},
+		},
+		{
+			fixture:  "quoted_printable.eml",
+			merchant: "Trailhead Outfitters",
+			amount:   231.64,
+			currency: "USD",
+			date:     "2024-06-04T15:45:00-05:00",
+		},
+		{
+			fixture:  "european.eml",
+			merchant: "Bäckerei Müller",
+			amount:   1234.56,
+			currency: "EUR",
+			date:     "2024-06-07T07:30:00+02:00",
+		},
+		{
+			fixture:  "rfc822_inline.eml",
+			merchant: "Northwind Airlines",
+			amount:   360.20,
+			currency: "USD",
+			date:     "2024-06-02T21:05:00Z",
+		},
+		{
+			fixture:  "subtotal_last.eml",
+			merchant: "Corner Market",
+			amount:   13.08,
+			currency: "USD",
+			date:     "2024-06-08T11:20:00Z",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.TrimSuffix(tt.fixture, ".eml"), func(t *testing.T) {
+			file, err := os.Open(filepath.Join("testdata", tt.fixture))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer file.Close()
+
+			receipt, err := ParseReceiptEmail(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if receipt.Merchant != tt.merchant {
+				t.Errorf("merchant = %q, want %q", receipt.Merchant, tt.merchant)
+			}
+			if receipt.Amount == nil || *receipt.Amount != tt.amount || receipt.Currency != tt.currency {
+				t.Errorf("total = %v %q, want %v %q", receipt.Amount, receipt.Currency, tt.amount, tt.currency)
+			}
+			want, _ := time.Parse(time.RFC3339, tt.date)
+			if receipt.Date == nil || !receipt.Date.Equal(want) {
+				t.Errorf("date = %v, want %v", receipt.Date, want)
+			}
+			if len(receipt.Attachments) != len(tt.attachments) {
+				t.Fatalf("attachments = %+v, want %+v", receipt.Attachments, tt.attachments)
+			}
+			for i, want := range tt.attachments {
+				got := receipt.Attachments[i]
+				if got.Filename != want.Filename || got.ContentType != want.ContentType || got.Size != want.Size || len(got.Data) != want.Size {
+					t.Errorf("attachment %d = %s %s %d bytes, want %+v", i, got.Filename, got.ContentType, len(got.Data), want)
+				}
+			}
+		})
+	}
+}
+
+func TestParseForwardedDate(t *testing.T) {
+	est := time.FixedZone("EST", -5*60*60)
+	tests := []struct {
+		value string
+		want  time.Time
+		ok    bool
+	}{
+		{"Mon, 3 Jun 2024 10:12:00 +0000", time.Date(2024, 6, 3, 10, 12, 0, 0, time.UTC), true},
+		{"Mon, Jun 3, 2024 at 10:12 AM", time.Date(2024, 6, 3, 10, 12, 0, 0, est), true},
+		{"Mon, Jun 3, 2024 at 10:12 PM", time.Date(2024, 6, 3, 22, 12, 0, 0, est), true},
+		{"Mon, Jun 3, 2024 at 10:12\u202fAM", time.Date(2024, 6, 3, 10, 12, 0, 0, est), true},
+		{"Monday, June 3, 2024 10:12 AM", time.Date(2024, 6, 3, 10, 12, 0, 0, est), true},
+		{"Monday, June 3, 2024 at 10:12 AM", time.Date(2024, 6, 3, 10, 12, 0, 0, est), true},
+		{"yesterday", time.Time{}, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseForwardedDate(tt.value, est)
+		if ok != tt.ok || !got.Equal(tt.want) {
+			t.Errorf("parseForwardedDate(%q) = %v %v, want %v %v", tt.value, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestParseReceiptAmount(t *testing.T) {
+	tests := []struct {
+		value    string
+		amount   float64
+		currency string
+	}{
+		{"$1,234.56", 1234.56, "USD"},
+		{"$1,234", 1234, "USD"},
+		{"Total: $12,345,678", 12345678, "USD"},
+		{"1.234 €", 1234, "EUR"},
+		{"1.234,56 €", 1234.56, "EUR"},
+		{"€ 12,50", 12.50, "EUR"},
+		{"£7.99", 7.99, "GBP"},
+		{"42.00 EUR", 42, "EUR"},
+		{"19.99", 19.99, ""},
+	}
+
+	for _, tt := range tests {
+		amount, currency, ok := parseReceiptAmount(tt.value)
+		if !ok || amount != tt.amount || currency != tt.currency {
+			t.Errorf("parseReceiptAmount(%q) = %v %q %v, want %v %q", tt.value, amount, currency, ok, tt.amount, tt.currency)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package services
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+)
+
+const (
+	// ThumbnailSize is the longest side of a generated thumbnail, in pixels
+	ThumbnailSize = 256
+	// maxThumbnailSourcePixels guards against decompression bombs
+	maxThumbnailSourcePixels = 50_000_000
+)
+
+// MakeThumbnail scales a JPEG, PNG or GIF image down to fit ThumbnailSize
+// and encodes it as JPEG. Smaller images keep their size.
+func MakeThumbnail(data []byte) ([]byte, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.New("unsupported image: " + err.Error())
+	}
+	if config.Width*config.Height > maxThumbnailSourcePixels {
+		return nil, errors.New("image too large for a thumbnail")
+	}
+
+	source, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.New("unsupported image: " + err.Error())
+	}
+
+	thumbnail := scaleDown(source, ThumbnailSize)
+	var buffer bytes.Buffer
+	if err := jpeg.Encode(&buffer, thumbnail, &jpeg.Options{Quality: 80}); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// scaleDown fits the image into maxSide by averaging the source pixels
+// that fall into each target pixel
+func scaleDown(source image.Image, maxSide int) image.Image {
+	bounds := source.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	targetWidth, targetHeight := width, height
+	if width > maxSide || height > maxSide {
+		if width > height {
+			targetWidth, targetHeight = maxSide, max(1, height*maxSide/width)
+		} else {
+			targetWidth, targetHeight = max(1, width*maxSide/height), maxSide
+		}
+	}
+
+	target := image.NewRGBA(image.Rect(0, 0, targetWidth, targetHeight))
+	for y := 0; y < targetHeight; y++ {
+		y0 := bounds.Min.Y + y*height/targetHeight
+		y1 := max(y0+1, bounds.Min.Y+(y+1)*height/targetHeight)
+		for x := 0; x < targetWidth; x++ {
+			x0 := bounds.Min.X + x*width/targetWidth
+			x1 := max(x0+1, bounds.Min.X+(x+1)*width/targetWidth)
+
+			// JPEG has no alpha, so transparent pixels are put on white
+			var r, g, b, count uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					pr, pg, pb, pa := source.At(sx, sy).RGBA()
+					background := uint64(0xffff - pa)
+					r += uint64(pr) + background
+					g += uint64(pg) + background
+					b += uint64(pb) + background
+					count++
+				}
+			}
+			target.Set(x, y, color.RGBA64{
+				R: uint16(r / count),
+				G: uint16(g / count),
+				B: uint16(b / count),
+				A: 0xffff,
+			})
+		}
+	}
+	return target
+}
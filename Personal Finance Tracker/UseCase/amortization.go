@@ -0,0 +1,237 @@
+package usecase
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"personal-finance-tracker/domain/entities"
+)
+
+// maxPayoffYears stops schedules and simulations that would never finish
+const maxPayoffYears = 100
+
+var periodsPerYear = map[string]int{
+	entities.FrequencyDaily:    365,
+	entities.FrequencyWeekly:   52,
+	entities.FrequencyBiweekly: 26,
+	entities.FrequencyMonthly:  12,
+	entities.FrequencyAnnually: 1,
+}
+
+func roundCents(value float64) float64 {
+	return math.Round(value*100) / 100
+}
+
+// periodicRate converts an APR with the given compounding into the
+// effective interest rate of one payment period
+func periodicRate(apr float64, compounding, paymentFrequency string) float64 {
+	n := float64(periodsPerYear[compounding])
+	p := float64(periodsPerYear[paymentFrequency])
+	return math.Pow(1+apr/100/n, n/p) - 1
+}
+
+// paymentDate returns the date of the nth payment after start
+func paymentDate(start time.Time, frequency string, n int) time.Time {
+	switch frequency {
+	case entities.FrequencyWeekly:
+		return start.AddDate(0, 0, 7*n)
+	case entities.FrequencyBiweekly:
+		return start.AddDate(0, 0, 14*n)
+	case entities.FrequencyAnnually:
+		return start.AddDate(n, 0, 0)
+	default:
+		return start.AddDate(0, n, 0)
+	}
+}
+
+// scheduledPayment is the regular payment of a debt. Loans given only a
+// term get the standard annuity payment for that term.
+func scheduledPayment(debt *entities.Debt) (float64, error) {
+	if debt.PaymentAmount > 0 {
+		return debt.PaymentAmount, nil
+	}
+	if debt.TermMonths <= 0 {
+		return 0, errors.New("payment amount or term is required")
+	}
+
+	periods := float64(debt.TermMonths*periodsPerYear[debt.PaymentFrequency]) / 12
+	rate := periodicRate(debt.APR, debt.Compounding, debt.PaymentFrequency)
+	if rate == 0 {
+		return roundCents(debt.Principal / periods), nil
+	}
+	return roundCents(debt.Principal * rate / (1 - math.Pow(1+rate, -periods))), nil
+}
+
+// extraPaymentsBetween sums the extra payments that apply to the payment
+// made on date, given the previous payment was made on previous
+func extraPaymentsBetween(payments []entities.ExtraPayment, previous, date time.Time) float64 {
+	total := 0.0
+	for _, payment := range payments {
+		if payment.Date.After(date) {
+			continue
+		}
+		if payment.Recurring || payment.Date.After(previous) {
+			total += payment.Amount
+		}
+	}
+	return total
+}
+
+// buildAmortization generates the full payment table of a debt from its
+// original principal and start date, applying extra payments on their dates
+func buildAmortization(debt *entities.Debt) (*entities.AmortizationSchedule, error) {
+	payment, err := scheduledPayment(debt)
+	if err != nil {
+		return nil, err
+	}
+	rate := periodicRate(debt.APR, debt.Compounding, debt.PaymentFrequency)
+	balance := debt.Principal
+	if roundCents(balance*rate) >= payment {
+		return nil, errors.New("payment amount does not cover the interest")
+	}
+
+	schedule := &entities.AmortizationSchedule{
+		DebtID: debt.ID,
+		Rows:   []entities.AmortizationRow{},
+	}
+	maxPeriods := maxPayoffYears * periodsPerYear[debt.PaymentFrequency]
+	previous := debt.StartDate
+
+	for n := 1; balance > 0 && n <= maxPeriods; n++ {
+		date := paymentDate(debt.StartDate, debt.PaymentFrequency, n)
+		interest := roundCents(balance * rate)
+		owed := balance + interest
+
+		regular := math.Min(payment, owed)
+		extra := math.Min(extraPaymentsBetween(debt.ExtraPayments, previous, date), owed-regular)
+		principal := roundCents(regular + extra - interest)
+		balance = roundCents(balance - principal)
+
+		schedule.Rows = append(schedule.Rows, entities.AmortizationRow{
+			Number:    n,
+			Date:      date,
+			Payment:   roundCents(regular),
+			Principal: principal,
+			Interest:  interest,
+			Extra:     roundCents(extra),
+			Balance:   balance,
+		})
+		schedule.TotalPaid += regular + extra
+		schedule.TotalInterest += interest
+		schedule.PayoffDate = date
+		previous = date
+	}
+
+	if balance > 0 {
+		return nil, errors.New("debt is not paid off within 100 years")
+	}
+	schedule.TotalPaid = roundCents(schedule.TotalPaid)
+	schedule.TotalInterest = roundCents(schedule.TotalInterest)
+	return schedule, nil
+}
+
+type simulatedDebt struct {
+	debt           *entities.Debt
+	balance        float64
+	monthlyRate    float64
+	minimumPayment float64
+	payoff         entities.DebtPayoff
+}
+
+// simulatePayoff pays every debt down month by month. All minimum payments
+// are made first, then the rest of the budget goes to the debt the strategy
+// picks: the smallest balance (snowball) or the highest APR (avalanche).
+// Minimums of debts that are paid off roll into the budget.
+func simulatePayoff(debts []entities.Debt, strategy string, extraBudget float64, start time.Time) (*entities.PayoffPlan, error) {
+	plan := &entities.PayoffPlan{Strategy: strategy, Order: []entities.DebtPayoff{}}
+	var active []*simulatedDebt
+
+	for i := range debts {
+		debt := &debts[i]
+		if debt.CurrentBalance <= 0 {
+			continue
+		}
+		payment, err := scheduledPayment(debt)
+		if err != nil {
+			return nil, errors.New(debt.Name + ": " + err.Error())
+		}
+		// Express non-monthly payments as their monthly equivalent
+		payment = payment * float64(periodsPerYear[debt.PaymentFrequency]) / 12
+		active = append(active, &simulatedDebt{
+			debt:           debt,
+			balance:        debt.CurrentBalance,
+			monthlyRate:    periodicRate(debt.APR, debt.Compounding, entities.FrequencyMonthly),
+			minimumPayment: payment,
+			payoff:         entities.DebtPayoff{DebtID: debt.ID, Name: debt.Name},
+		})
+		plan.MonthlyBudget += payment
+	}
+	plan.MonthlyBudget = roundCents(plan.MonthlyBudget + extraBudget)
+
+	for month := 1; len(active) > 0; month++ {
+		if month > maxPayoffYears*12 {
+			return nil, errors.New("debts are not paid off within 100 years with this budget")
+		}
+
+		available := plan.MonthlyBudget
+		for _, d := range active {
+			interest := roundCents(d.balance * d.monthlyRate)
+			d.balance += interest
+			d.payoff.InterestPaid += interest
+			plan.TotalInterest += interest
+		}
+
+		for _, d := range active {
+			paid := math.Min(math.Min(d.minimumPayment, d.balance), available)
+			d.balance = roundCents(d.balance - paid)
+			available -= paid
+		}
+
+		sortForStrategy(active, strategy)
+		for _, d := range active {
+			if available <= 0 {
+				break
+			}
+			paid := math.Min(available, d.balance)
+			d.balance = roundCents(d.balance - paid)
+			available -= paid
+		}
+		plan.TotalPaid += plan.MonthlyBudget - available
+
+		remaining := active[:0]
+		for _, d := range active {
+			if d.balance > 0 {
+				remaining = append(remaining, d)
+				continue
+			}
+			d.payoff.PaidOffMonth = month
+			d.payoff.InterestPaid = roundCents(d.payoff.InterestPaid)
+			plan.Order = append(plan.Order, d.payoff)
+		}
+		active = remaining
+		plan.MonthsToPayoff = month
+	}
+
+	plan.TotalInterest = roundCents(plan.TotalInterest)
+	plan.TotalPaid = roundCents(plan.TotalPaid)
+	plan.PayoffDate = start.AddDate(0, plan.MonthsToPayoff, 0)
+	return plan, nil
+}
+
+func sortForStrategy(debts []*simulatedDebt, strategy string) {
+	sort.SliceStable(debts, func(i, j int) bool {
+		a, b := debts[i], debts[j]
+		if strategy == entities.PayoffStrategySnowball {
+			if a.balance != b.balance {
+				return a.balance < b.balance
+			}
+			return a.debt.APR > b.debt.APR
+		}
+		if a.debt.APR != b.debt.APR {
+			return a.debt.APR > b.debt.APR
+		}
+		return a.balance < b.balance
+	})
+}
@@ -0,0 +1,221 @@
+package usecase
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"personal-finance-tracker/domain/entities"
+)
+
+func TestScheduledPayment(t *testing.T) {
+	tests := []struct {
+		name    string
+		debt    entities.Debt
+		want    float64
+		wantErr bool
+	}{
+		{
+			name: "30 year mortgage",
+			debt: entities.Debt{Principal: 200000, APR: 6, TermMonths: 360, Compounding: entities.FrequencyMonthly, PaymentFrequency: entities.FrequencyMonthly},
+			want: 1199.10,
+		},
+		{
+			name: "interest free loan",
+			debt: entities.Debt{Principal: 1200, TermMonths: 12, Compounding: entities.FrequencyMonthly, PaymentFrequency: entities.FrequencyMonthly},
+			want: 100,
+		},
+		{
+			name: "explicit payment wins over term",
+			debt: entities.Debt{Principal: 1000, APR: 10, PaymentAmount: 75, TermMonths: 12, Compounding: entities.FrequencyMonthly, PaymentFrequency: entities.FrequencyMonthly},
+			want: 75,
+		},
+		{
+			name:    "neither payment nor term",
+			debt:    entities.Debt{Principal: 1000, APR: 10, Compounding: entities.FrequencyMonthly, PaymentFrequency: entities.FrequencyMonthly},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := scheduledPayment(&tt.debt)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("payment = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPeriodicRate(t *testing.T) {
+	tests := []struct {
+		name                      string
+		apr                       float64
+		compounding, paymentEvery string
+		want                      float64
+	}{
+		{"monthly on monthly", 12, entities.FrequencyMonthly, entities.FrequencyMonthly, 0.01},
+		{"annual on annual", 5, entities.FrequencyAnnually, entities.FrequencyAnnually, 0.05},
+		{"monthly compounding, annual payments", 12, entities.FrequencyMonthly, entities.FrequencyAnnually, math.Pow(1.01, 12) - 1},
+		{"zero rate", 0, entities.FrequencyDaily, entities.FrequencyWeekly, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := periodicRate(tt.apr, tt.compounding, tt.paymentEvery); math.Abs(got-tt.want) > 1e-12 {
+				t.Errorf("rate = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildAmortization(t *testing.T) {
+	start := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	loan := func(extras ...entities.ExtraPayment) entities.Debt {
+		return entities.Debt{
+			Principal:        10000,
+			APR:              6,
+			TermMonths:       24,
+			Compounding:      entities.FrequencyMonthly,
+			PaymentFrequency: entities.FrequencyMonthly,
+			StartDate:        start,
+			ExtraPayments:    extras,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		debt     entities.Debt
+		wantRows int
+		wantErr  bool
+	}{
+		{name: "pays off over its term", debt: loan(), wantRows: 24},
+		{
+			name:     "one-off extra payment shortens the schedule",
+			debt:     loan(entities.ExtraPayment{Amount: 3000, Date: start.AddDate(0, 2, 0)}),
+			wantRows: 17,
+		},
+		{
+			name:     "recurring extra payment applies every period",
+			debt:     loan(entities.ExtraPayment{Amount: 200, Date: start, Recurring: true}),
+			wantRows: 17,
+		},
+		{
+			name:    "payment below the interest never pays off",
+			debt:    entities.Debt{Principal: 10000, APR: 12, PaymentAmount: 100, Compounding: entities.FrequencyMonthly, PaymentFrequency: entities.FrequencyMonthly, StartDate: start},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := buildAmortization(&tt.debt)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(schedule.Rows) != tt.wantRows {
+				t.Errorf("rows = %d, want %d", len(schedule.Rows), tt.wantRows)
+			}
+
+			principal, interest := 0.0, 0.0
+			for _, row := range schedule.Rows {
+				principal += row.Principal
+				interest += row.Interest
+			}
+			last := schedule.Rows[len(schedule.Rows)-1]
+			if last.Balance != 0 {
+				t.Errorf("final balance = %v, want 0", last.Balance)
+			}
+			if roundCents(principal) != tt.debt.Principal {
+				t.Errorf("principal repaid = %v, want %v", roundCents(principal), tt.debt.Principal)
+			}
+			if roundCents(interest) != schedule.TotalInterest {
+				t.Errorf("interest rows = %v, total %v", roundCents(interest), schedule.TotalInterest)
+			}
+			if roundCents(principal+interest) != schedule.TotalPaid {
+				t.Errorf("total paid = %v, want %v", schedule.TotalPaid, roundCents(principal+interest))
+			}
+			if !schedule.PayoffDate.Equal(last.Date) {
+				t.Errorf("payoff date = %v, want %v", schedule.PayoffDate, last.Date)
+			}
+		})
+	}
+}
+
+func TestSimulatePayoffOrder(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	debt := func(name string, balance, apr, payment float64) entities.Debt {
+		return entities.Debt{
+			Name:             name,
+			Principal:        balance,
+			CurrentBalance:   balance,
+			APR:              apr,
+			PaymentAmount:    payment,
+			Compounding:      entities.FrequencyMonthly,
+			PaymentFrequency: entities.FrequencyMonthly,
+		}
+	}
+	debts := func() []entities.Debt {
+		return []entities.Debt{
+			debt("card", 5000, 24, 150),
+			debt("store", 800, 10, 40),
+			debt("car", 9000, 5, 250),
+		}
+	}
+
+	tests := []struct {
+		strategy  string
+		wantFirst string
+	}{
+		{entities.PayoffStrategySnowball, "store"},
+		{entities.PayoffStrategyAvalanche, "card"},
+	}
+
+	interest := map[string]float64{}
+	for _, tt := range tests {
+		t.Run(tt.strategy, func(t *testing.T) {
+			plan, err := simulatePayoff(debts(), tt.strategy, 300, start)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(plan.Order) != 3 {
+				t.Fatalf("paid off %d debts, want 3", len(plan.Order))
+			}
+			if plan.Order[0].Name != tt.wantFirst {
+				t.Errorf("first paid off = %s, want %s", plan.Order[0].Name, tt.wantFirst)
+			}
+			if plan.MonthlyBudget != 740 {
+				t.Errorf("budget = %v, want minimums plus extra (740)", plan.MonthlyBudget)
+			}
+			if !plan.PayoffDate.Equal(start.AddDate(0, plan.MonthsToPayoff, 0)) {
+				t.Errorf("payoff date %v does not match %d months", plan.PayoffDate, plan.MonthsToPayoff)
+			}
+			interest[tt.strategy] = plan.TotalInterest
+		})
+	}
+
+	if interest[entities.PayoffStrategyAvalanche] > interest[entities.PayoffStrategySnowball] {
+		t.Errorf("avalanche interest %v exceeds snowball %v", interest[entities.PayoffStrategyAvalanche], interest[entities.PayoffStrategySnowball])
+	}
+}
+
+func TestSimulatePayoffBudgetTooSmall(t *testing.T) {
+	debts := []entities.Debt{{
+		Name:             "card",
+		Principal:        10000,
+		CurrentBalance:   10000,
+		APR:              30,
+		PaymentAmount:    100,
+		Compounding:      entities.FrequencyMonthly,
+		PaymentFrequency: entities.FrequencyMonthly,
+	}}
+	if _, err := simulatePayoff(debts, entities.PayoffStrategyAvalanche, 0, time.Now()); err == nil {
+		t.Fatal("expected an error for a budget below the interest")
+	}
+}
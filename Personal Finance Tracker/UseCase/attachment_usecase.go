@@ -0,0 +1,299 @@
+package usecase
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"personal-finance-tracker/Infrastructure/service"
+	"personal-finance-tracker/domain/entities"
+	repoInterface "personal-finance-tracker/domain/interface"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	MaxAttachmentSize = 10 << 20
+	// attachmentQuota is the total a single user may store
+	attachmentQuota = 200 << 20
+)
+
+// Content types accepted, as sniffed from the file itself
+var attachmentContentTypes = map[string]bool{
+	"image/jpeg":      true,
+	"image/png":       true,
+	"image/gif":       true,
+	"image/webp":      true,
+	"application/pdf": true,
+}
+
+var thumbnailContentTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+}
+
+type AttachmentUsecase struct {
+	attachmentRepo repoInterface.AttachmentRepository
+	fileStore      repoInterface.FileStore
+	// parentLookups check that a parent record exists and belongs to the user
+	parentLookups map[string]func(id, userID string) error
+}
+
+func NewAttachmentUsecase(
+	attachmentRepo repoInterface.AttachmentRepository,
+	fileStore repoInterface.FileStore,
+	billRepo repoInterface.BillRepository,
+	debtRepo repoInterface.DebtRepository,
+	goalRepo repoInterface.GoalRepository,
+	accountRepo repoInterface.InvestmentAccountRepository,
+	receiptRepo repoInterface.ReceiptRepository,
+) *AttachmentUsecase {
+	return &AttachmentUsecase{
+		attachmentRepo: attachmentRepo,
+		fileStore:      fileStore,
+		parentLookups: map[string]func(id, userID string) error{
+			entities.AttachmentParentBill: func(id, userID string) error {
+				_, err := billRepo.GetBillByID(id, userID)
+				return err
+			},
+			entities.AttachmentParentDebt: func(id, userID string) error {
+				_, err := debtRepo.GetDebtByID(id, userID)
+				return err
+			},
+			entities.AttachmentParentGoal: func(id, userID string) error {
+				_, err := goalRepo.GetGoalByID(id, userID)
+				return err
+			},
+			entities.AttachmentParentInvestmentAccount: func(id, userID string) error {
+				_, err := accountRepo.GetAccountByID(id, userID)
+				return err
+			},
+			entities.AttachmentParentReceipt: func(id, userID string) error {
+				_, err := receiptRepo.GetReceiptByID(id, userID)
+				return err
+			},
+		},
+	}
+}
+
+// Upload stores a file against a parent record. The content type is
+// sniffed from the data, not taken from the client, and images also get a
+// thumbnail.
+func (u *AttachmentUsecase) Upload(userID, parentType, parentID, filename string, data io.Reader) (*entities.Attachment, error) {
+	ownerID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, errors.New("invalid user id")
+	}
+	lookup, ok := u.parentLookups[parentType]
+	if !ok {
+		return nil, errors.New("invalid parent type: must be bill, debt, goal, investment_account or receipt")
+	}
+	if err := lookup(parentID, userID); err != nil {
+		return nil, err
+	}
+	parentObjectID, _ := primitive.ObjectIDFromHex(parentID)
+
+	content, err := io.ReadAll(io.LimitReader(data, MaxAttachmentSize+1))
+	if err != nil {
+		return nil, errors.New("invalid file: " + err.Error())
+	}
+	if len(content) == 0 {
+		return nil, errors.New("invalid file: empty")
+	}
+	if len(content) > MaxAttachmentSize {
+		return nil, errors.New("file too large: at most 10 MB")
+	}
+	contentType := strings.Split(http.DetectContentType(content), ";")[0]
+	if !attachmentContentTypes[contentType] {
+		return nil, errors.New("unsupported file type: " + contentType)
+	}
+
+	return u.store(ownerID, parentType, parentObjectID, filename, contentType, content)
+}
+
+// store saves checked content as an attachment of a parent record, with a
+// thumbnail for images, as long as the user stays within the quota
+func (u *AttachmentUsecase) store(ownerID primitive.ObjectID, parentType string, parentID primitive.ObjectID, filename, contentType string, content []byte) (*entities.Attachment, error) {
+	userID := ownerID.Hex()
+
+	var thumbnail []byte
+	if thumbnailContentTypes[contentType] {
+		var err error
+		if thumbnail, err = services.MakeThumbnail(content); err != nil {
+			log.Printf("⚠️ No thumbnail for %s: %v", filename, err)
+			thumbnail = nil
+		}
+	}
+
+	used, err := u.attachmentRepo.TotalSize(userID)
+	if err != nil {
+		return nil, errors.New("Database error: " + err.Error())
+	}
+	if used+int64(len(content))+int64(len(thumbnail)) > attachmentQuota {
+		return nil, fmt.Errorf("storage quota exceeded: %d of %d bytes used", used, int64(attachmentQuota))
+	}
+
+	filename = filepath.Base(strings.TrimSpace(filename))
+	if filename == "." || filename == string(filepath.Separator) {
+		filename = "attachment"
+	}
+
+	fileID, err := u.fileStore.Upload(filename, contentType, bytes.NewReader(content))
+	if err != nil {
+		return nil, errors.New("Failed to store file: " + err.Error())
+	}
+
+	attachment := &entities.Attachment{
+		UserID:      ownerID,
+		ParentType:  parentType,
+		ParentID:    parentID,
+		Filename:    filename,
+		ContentType: contentType,
+		Size:        int64(len(content)),
+		FileID:      fileID,
+		CreatedAt:   time.Now(),
+	}
+
+	// A missing thumbnail is not worth failing the upload for
+	if thumbnail != nil {
+		if thumbnailID, err := u.fileStore.Upload("thumb-"+filename, "image/jpeg", bytes.NewReader(thumbnail)); err != nil {
+			log.Printf("⚠️ Failed to store thumbnail for %s: %v", filename, err)
+		} else {
+			attachment.ThumbnailID = &thumbnailID
+			attachment.ThumbnailSize = int64(len(thumbnail))
+		}
+	}
+
+	createdAttachment, err := u.attachmentRepo.CreateAttachment(attachment)
+	if err != nil {
+		u.deleteFiles(attachment)
+		return nil, errors.New("Failed to create attachment: " + err.Error())
+	}
+
+	// Concurrent uploads can each pass the check above, so check again now
+	// that this one counts and take it back if the user went over
+	used, err = u.attachmentRepo.TotalSize(userID)
+	if err == nil && used > attachmentQuota {
+		if err := u.attachmentRepo.DeleteAttachment(createdAttachment.ID.Hex(), userID); err != nil {
+			log.Printf("⚠️ Failed to remove attachment %s over quota: %v", createdAttachment.ID.Hex(), err)
+		} else {
+			u.deleteFiles(createdAttachment)
+		}
+		return nil, fmt.Errorf("storage quota exceeded: %d of %d bytes used", used-createdAttachment.Size-createdAttachment.ThumbnailSize, int64(attachmentQuota))
+	}
+
+	createdAttachment.HasThumbnail = createdAttachment.ThumbnailID != nil
+	return createdAttachment, nil
+}
+
+func (u *AttachmentUsecase) GetAttachment(userID, id string) (*entities.Attachment, error) {
+	attachment, err := u.attachmentRepo.GetAttachmentByID(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	attachment.HasThumbnail = attachment.ThumbnailID != nil
+	return attachment, nil
+}
+
+func (u *AttachmentUsecase) ListAttachments(userID, parentType, parentID string, page entities.PageRequest) (*entities.Page[entities.Attachment], error) {
+	if parentType != "" {
+		if _, ok := u.parentLookups[parentType]; !ok {
+			return nil, errors.New("invalid parent type: must be bill, debt, goal, investment_account or receipt")
+		}
+	}
+
+	attachments, err := u.attachmentRepo.ListAttachments(userID, parentType, parentID, page)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "invalid") {
+			return nil, err
+		}
+		return nil, errors.New("Database error: " + err.Error())
+	}
+	for i := range attachments.Items {
+		attachments.Items[i].HasThumbnail = attachments.Items[i].ThumbnailID != nil
+	}
+	return attachments, nil
+}
+
+// Open returns the attachment and a reader over its contents, or over its
+// thumbnail when thumbnail is set. The caller closes the reader.
+func (u *AttachmentUsecase) Open(userID, id string, thumbnail bool) (*entities.Attachment, io.ReadCloser, error) {
+	attachment, err := u.GetAttachment(userID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fileID := attachment.FileID
+	if thumbnail {
+		if attachment.ThumbnailID == nil {
+			return nil, nil, errors.New("thumbnail not found")
+		}
+		fileID = *attachment.ThumbnailID
+	}
+
+	reader, err := u.fileStore.Open(fileID)
+	if err != nil {
+		if err.Error() == "file not found" {
+			return nil, nil, err
+		}
+		return nil, nil, errors.New("Database error: " + err.Error())
+	}
+	return attachment, reader, nil
+}
+
+func (u *AttachmentUsecase) DeleteAttachment(userID, id string) error {
+	attachment, err := u.attachmentRepo.GetAttachmentByID(id, userID)
+	if err != nil {
+		return err
+	}
+	if err := u.attachmentRepo.DeleteAttachment(id, userID); err != nil {
+		return err
+	}
+	u.deleteFiles(attachment)
+	return nil
+}
+
+// DeleteParentAttachments removes the attachments and stored files of a
+// parent record that is being deleted
+func (u *AttachmentUsecase) DeleteParentAttachments(userID, parentType, parentID string) error {
+	attachments, err := u.attachmentRepo.DeleteAttachmentsByParent(userID, parentType, parentID)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "invalid") {
+			return err
+		}
+		return errors.New("Database error: " + err.Error())
+	}
+	for i := range attachments {
+		u.deleteFiles(&attachments[i])
+	}
+	return nil
+}
+
+func (u *AttachmentUsecase) GetUsage(userID string) (*entities.AttachmentUsage, error) {
+	used, err := u.attachmentRepo.TotalSize(userID)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "invalid") {
+			return nil, err
+		}
+		return nil, errors.New("Database error: " + err.Error())
+	}
+	return &entities.AttachmentUsage{Used: used, Quota: attachmentQuota}, nil
+}
+
+func (u *AttachmentUsecase) deleteFiles(attachment *entities.Attachment) {
+	if err := u.fileStore.Delete(attachment.FileID); err != nil {
+		log.Printf("⚠️ Failed to delete file %s: %v", attachment.FileID.Hex(), err)
+	}
+	if attachment.ThumbnailID != nil {
+		if err := u.fileStore.Delete(*attachment.ThumbnailID); err != nil {
+			log.Printf("⚠️ Failed to delete thumbnail %s: %v", attachment.ThumbnailID.Hex(), err)
+		}
+	}
+}
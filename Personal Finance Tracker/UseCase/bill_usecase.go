@@ -0,0 +1,380 @@
+package usecase
+
+import (
+	"errors"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"personal-finance-tracker/Infrastructure/service"
+	"personal-finance-tracker/domain/entities"
+	repoInterface "personal-finance-tracker/domain/interface"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	defaultReminderDays = 3
+	maxReminderDays     = 30
+	// missedLookbackDays is how far back unpaid due dates are reported as missed
+	missedLookbackDays = 30
+)
+
+var billFrequencyMonths = map[string]int{
+	entities.BillFrequencyMonthly:   1,
+	entities.BillFrequencyQuarterly: 3,
+	entities.BillFrequencyAnnually:  12,
+}
+
+type BillUsecase struct {
+	billRepo     repoInterface.BillRepository
+	userRepo     repoInterface.UserRepository
+	emailService *services.EmailService
+	// attachmentUsecase removes a deleted bill's attachments
+	attachmentUsecase *AttachmentUsecase
+}
+
+func NewBillUsecase(billRepo repoInterface.BillRepository, userRepo repoInterface.UserRepository, emailService *services.EmailService, attachmentUsecase *AttachmentUsecase) *BillUsecase {
+	return &BillUsecase{
+		billRepo:          billRepo,
+		userRepo:          userRepo,
+		emailService:      emailService,
+		attachmentUsecase: attachmentUsecase,
+	}
+}
+
+func (u *BillUsecase) CreateBill(userID string, bill *entities.Bill) (*entities.Bill, error) {
+	ownerID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, errors.New("invalid user id")
+	}
+	if err := validateBill(bill); err != nil {
+		return nil, err
+	}
+	if bill.StartDate.IsZero() {
+		bill.StartDate = startOfDay(time.Now())
+	}
+
+	bill.ID = primitive.NilObjectID
+	bill.UserID = ownerID
+	bill.Active = true
+	bill.Payments = []entities.BillPayment{}
+	bill.LastRemindedDue = nil
+	bill.CreatedAt = time.Now()
+	bill.UpdatedAt = time.Now()
+
+	createdBill, err := u.billRepo.CreateBill(bill)
+	if err != nil {
+		return nil, errors.New("Failed to create bill: " + err.Error())
+	}
+	return createdBill, nil
+}
+
+func (u *BillUsecase) GetBill(userID, id string) (*entities.Bill, error) {
+	return u.billRepo.GetBillByID(id, userID)
+}
+
+func (u *BillUsecase) ListBills(userID string, page entities.PageRequest) (*entities.Page[entities.Bill], error) {
+	bills, err := u.billRepo.ListBills(userID, page)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "invalid") {
+			return nil, err
+		}
+		return nil, errors.New("Database error: " + err.Error())
+	}
+	return bills, nil
+}
+
+// UpdateBill replaces the editable fields. A nil active or a missing start
+// date keeps the bill's current value, and changing the schedule clears the reminder marker so
+// the first due date of the new schedule is still reminded.
+func (u *BillUsecase) UpdateBill(userID, id string, input *entities.Bill, active *bool) (*entities.Bill, error) {
+	bill, err := u.billRepo.GetBillByID(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateBill(input); err != nil {
+		return nil, err
+	}
+	if input.StartDate.IsZero() {
+		input.StartDate = bill.StartDate
+	}
+
+	if input.DueDay != bill.DueDay || input.Frequency != bill.Frequency || !input.StartDate.Equal(bill.StartDate) {
+		bill.LastRemindedDue = nil
+	}
+	bill.Payee = input.Payee
+	bill.Category = input.Category
+	bill.AmountMin = input.AmountMin
+	bill.AmountMax = input.AmountMax
+	bill.Currency = input.Currency
+	bill.DueDay = input.DueDay
+	bill.Frequency = input.Frequency
+	bill.StartDate = input.StartDate
+	bill.Autopay = input.Autopay
+	bill.ReminderDaysBefore = input.ReminderDaysBefore
+	if active != nil {
+		bill.Active = *active
+	}
+	bill.Notes = input.Notes
+	bill.UpdatedAt = time.Now()
+
+	return u.billRepo.UpdateBill(bill)
+}
+
+func (u *BillUsecase) DeleteBill(userID, id string) error {
+	if err := u.billRepo.DeleteBill(id, userID); err != nil {
+		return err
+	}
+	return u.attachmentUsecase.DeleteParentAttachments(userID, entities.AttachmentParentBill, id)
+}
+
+// RecordPayment marks the due date closest to the payment as paid and
+// flags amounts above the expected range
+func (u *BillUsecase) RecordPayment(userID, billID string, payment entities.BillPayment) (*entities.Bill, error) {
+	bill, err := u.billRepo.GetBillByID(billID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if payment.Amount <= 0 {
+		return nil, errors.New("payment amount must be positive")
+	}
+	if payment.Date.IsZero() {
+		payment.Date = time.Now()
+	}
+
+	// Look one full period either side of the payment for an unpaid due date
+	months := billFrequencyMonths[bill.Frequency]
+	candidates := billDueDates(bill, payment.Date.AddDate(0, -months, 0), payment.Date.AddDate(0, months, 0))
+	var dueDate time.Time
+	closest := math.MaxFloat64
+	for _, candidate := range candidates {
+		if findBillPayment(bill, candidate) != nil {
+			continue
+		}
+		if distance := math.Abs(payment.Date.Sub(candidate).Hours()); distance < closest {
+			closest = distance
+			dueDate = candidate
+		}
+	}
+	if dueDate.IsZero() {
+		return nil, errors.New("no unpaid due date near the payment date")
+	}
+
+	payment.ID = primitive.NewObjectID()
+	payment.DueDate = dueDate
+	payment.Unusual = payment.Amount > bill.AmountMax
+	return u.billRepo.AddPayment(billID, userID, payment)
+}
+
+func (u *BillUsecase) RemovePayment(userID, billID, paymentID string) (*entities.Bill, error) {
+	return u.billRepo.RemovePayment(billID, userID, paymentID)
+}
+
+// GetUpcoming lists the due dates of the next days, plus unpaid due dates
+// of the last 30 days so missed bills stay visible
+func (u *BillUsecase) GetUpcoming(userID string, days int) ([]entities.BillOccurrence, error) {
+	if days < 1 || days > 366 {
+		return nil, errors.New("invalid days: must be between 1 and 366")
+	}
+
+	bills, err := u.billRepo.GetActiveBills(userID)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "invalid") {
+			return nil, err
+		}
+		return nil, errors.New("Database error: " + err.Error())
+	}
+
+	today := startOfDay(time.Now())
+	return billOccurrences(bills, today, today.AddDate(0, 0, -missedLookbackDays), today.AddDate(0, 0, days)), nil
+}
+
+// SendDueReminders emails users about bills due within each bill's
+// reminder window. Autopay bills and paid due dates are skipped, and each
+// due date is only reminded once.
+func (u *BillUsecase) SendDueReminders(now time.Time) (int, error) {
+	bills, err := u.billRepo.GetAllActiveBills()
+	if err != nil {
+		return 0, errors.New("Database error: " + err.Error())
+	}
+
+	today := startOfDay(now)
+	sent := 0
+	for i := range bills {
+		bill := &bills[i]
+		if bill.Autopay {
+			continue
+		}
+
+		for _, dueDate := range billDueDates(bill, today, today.AddDate(0, 0, bill.ReminderDaysBefore)) {
+			if findBillPayment(bill, dueDate) != nil {
+				continue
+			}
+			if bill.LastRemindedDue != nil && !bill.LastRemindedDue.Before(dueDate) {
+				continue
+			}
+
+			user, err := u.userRepo.GetUserByID(bill.UserID.Hex())
+			if err != nil {
+				log.Printf("⚠️ Bill reminder skipped for %s: %v", bill.ID.Hex(), err)
+				break
+			}
+			if err := u.emailService.SendBillReminder(user.Email, user.Name, bill.Payee, dueDate, bill.AmountMin, bill.AmountMax, bill.Currency); err != nil {
+				log.Printf("⚠️ Failed to send bill reminder for %s: %v", bill.ID.Hex(), err)
+				break
+			}
+			if err := u.billRepo.SetLastReminded(bill.ID.Hex(), dueDate); err != nil {
+				log.Printf("⚠️ Failed to record bill reminder for %s: %v", bill.ID.Hex(), err)
+			}
+			sent++
+			break
+		}
+	}
+
+	return sent, nil
+}
+
+// StartReminderScheduler sends due reminders now and then on every interval
+func (u *BillUsecase) StartReminderScheduler(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			sent, err := u.SendDueReminders(time.Now())
+			if err != nil {
+				log.Printf("⚠️ Bill reminder job failed: %v", err)
+			} else if sent > 0 {
+				log.Printf("📧 Sent %d bill reminders", sent)
+			}
+			<-ticker.C
+		}
+	}()
+}
+
+// billOccurrences lists every due date of the bills between from and to,
+// sorted by date, with its paid, missed or upcoming status
+func billOccurrences(bills []entities.Bill, today, from, to time.Time) []entities.BillOccurrence {
+	occurrences := []entities.BillOccurrence{}
+	for i := range bills {
+		bill := &bills[i]
+		for _, dueDate := range billDueDates(bill, from, to) {
+			occurrence := entities.BillOccurrence{
+				BillID:    bill.ID,
+				Payee:     bill.Payee,
+				DueDate:   dueDate,
+				AmountMin: bill.AmountMin,
+				AmountMax: bill.AmountMax,
+				Currency:  bill.Currency,
+				Autopay:   bill.Autopay,
+				Status:    entities.BillStatusUpcoming,
+			}
+			if payment := findBillPayment(bill, dueDate); payment != nil {
+				occurrence.Status = entities.BillStatusPaid
+				occurrence.Payment = payment
+			} else if dueDate.Before(today) {
+				// Autopay bills pay themselves; only manual ones can be missed
+				if bill.Autopay {
+					continue
+				}
+				occurrence.Status = entities.BillStatusMissed
+			}
+			occurrences = append(occurrences, occurrence)
+		}
+	}
+
+	sort.SliceStable(occurrences, func(i, j int) bool {
+		return occurrences[i].DueDate.Before(occurrences[j].DueDate)
+	})
+	return occurrences
+}
+
+// billDueDates returns the due dates of a bill between from and to, inclusive
+func billDueDates(bill *entities.Bill, from, to time.Time) []time.Time {
+	months := billFrequencyMonths[bill.Frequency]
+	if months == 0 {
+		months = 1
+	}
+	start := startOfDay(bill.StartDate)
+	from = startOfDay(from)
+	to = startOfDay(to)
+
+	// Skip whole periods up to the month before from
+	period := 0
+	if elapsed := monthsBetween(start, from) - 1; elapsed > 0 {
+		period = elapsed / months
+	}
+
+	var dates []time.Time
+	for ; ; period++ {
+		month := time.Date(start.Year(), start.Month()+time.Month(period*months), 1, 0, 0, 0, 0, time.UTC)
+		day := bill.DueDay
+		if last := month.AddDate(0, 1, -1).Day(); day > last {
+			day = last
+		}
+		due := time.Date(month.Year(), month.Month(), day, 0, 0, 0, 0, time.UTC)
+		if due.After(to) {
+			break
+		}
+		if due.Before(start) || due.Before(from) {
+			continue
+		}
+		dates = append(dates, due)
+	}
+	return dates
+}
+
+func monthsBetween(from, to time.Time) int {
+	return (to.Year()-from.Year())*12 + int(to.Month()) - int(from.Month())
+}
+
+func findBillPayment(bill *entities.Bill, dueDate time.Time) *entities.BillPayment {
+	for i := range bill.Payments {
+		if bill.Payments[i].DueDate.Equal(dueDate) {
+			return &bill.Payments[i]
+		}
+	}
+	return nil
+}
+
+func validateBill(bill *entities.Bill) error {
+	bill.Payee = strings.TrimSpace(bill.Payee)
+	if bill.Payee == "" {
+		return errors.New("payee is required")
+	}
+	if bill.AmountMin < 0 || bill.AmountMax < 0 {
+		return errors.New("amounts cannot be negative")
+	}
+	if bill.AmountMax == 0 {
+		bill.AmountMax = bill.AmountMin
+	}
+	if bill.AmountMax == 0 {
+		return errors.New("expected amount is required")
+	}
+	if bill.AmountMin > bill.AmountMax {
+		return errors.New("amount_min cannot be greater than amount_max")
+	}
+	bill.Currency = strings.ToUpper(bill.Currency)
+	if bill.Currency != "" && !currencyCodeRegex.MatchString(bill.Currency) {
+		return errors.New("invalid currency code")
+	}
+	if bill.DueDay < 1 || bill.DueDay > 31 {
+		return errors.New("due day must be between 1 and 31")
+	}
+	if bill.Frequency == "" {
+		bill.Frequency = entities.BillFrequencyMonthly
+	}
+	if _, ok := billFrequencyMonths[bill.Frequency]; !ok {
+		return errors.New("invalid frequency: must be monthly, quarterly or annually")
+	}
+	if bill.ReminderDaysBefore == 0 {
+		bill.ReminderDaysBefore = defaultReminderDays
+	}
+	if bill.ReminderDaysBefore < 1 || bill.ReminderDaysBefore > maxReminderDays {
+		return errors.New("reminder days must be between 1 and 30")
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+package usecase
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"personal-finance-tracker/Infrastructure/service"
+	"personal-finance-tracker/domain/entities"
+	repoInterface "personal-finance-tracker/domain/interface"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// calendarHorizonDays is how far ahead the feed lists bill due dates
+	calendarHorizonDays = 365
+	goalAlarmDays       = 7
+)
+
+type CalendarUsecase struct {
+	feedRepo repoInterface.CalendarFeedRepository
+	billRepo repoInterface.BillRepository
+	goalRepo repoInterface.GoalRepository
+}
+
+func NewCalendarUsecase(feedRepo repoInterface.CalendarFeedRepository, billRepo repoInterface.BillRepository, goalRepo repoInterface.GoalRepository) *CalendarUsecase {
+	return &CalendarUsecase{
+		feedRepo: feedRepo,
+		billRepo: billRepo,
+		goalRepo: goalRepo,
+	}
+}
+
+// CreateFeedToken issues a new secret feed token for the user, revoking the
+// previous one. The token is only returned here; just its hash is stored.
+func (u *CalendarUsecase) CreateFeedToken(userID string) (string, error) {
+	ownerID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return "", errors.New("invalid user id")
+	}
+
+	token, err := newSecretToken()
+	if err != nil {
+		return "", errors.New("Failed to create calendar token: " + err.Error())
+	}
+
+	feed := &entities.CalendarFeed{
+		UserID:    ownerID,
+		TokenHash: hashSecretToken(token),
+		CreatedAt: time.Now(),
+	}
+	if _, err := u.feedRepo.ReplaceFeed(feed); err != nil {
+		return "", errors.New("Failed to create calendar token: " + err.Error())
+	}
+	return token, nil
+}
+
+func (u *CalendarUsecase) RevokeFeedToken(userID string) error {
+	return u.feedRepo.DeleteFeed(userID)
+}
+
+// RenderFeed builds the ICS document for the feed the token belongs to
+func (u *CalendarUsecase) RenderFeed(token string) ([]byte, error) {
+	if token == "" {
+		return nil, errors.New("calendar feed not found")
+	}
+	feed, err := u.feedRepo.GetFeedByTokenHash(hashSecretToken(token))
+	if err != nil {
+		if err.Error() == "calendar feed not found" {
+			return nil, err
+		}
+		return nil, errors.New("Database error: " + err.Error())
+	}
+
+	userID := feed.UserID.Hex()
+	now := time.Now()
+	today := startOfDay(now)
+
+	bills, err := u.billRepo.GetActiveBills(userID)
+	if err != nil {
+		return nil, errors.New("Database error: " + err.Error())
+	}
+	goals, err := u.goalRepo.GetGoalsWithDeadline(userID)
+	if err != nil {
+		return nil, errors.New("Database error: " + err.Error())
+	}
+
+	events := billCalendarEvents(bills, today, today.AddDate(0, 0, -missedLookbackDays), today.AddDate(0, 0, calendarHorizonDays))
+	events = append(events, goalCalendarEvents(goals, today)...)
+
+	var buffer bytes.Buffer
+	if err := services.WriteICS(&buffer, "Bills and goals", events, now); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func billCalendarEvents(bills []entities.Bill, today, from, to time.Time) []entities.CalendarEvent {
+	reminderDays := map[primitive.ObjectID]int{}
+	for _, bill := range bills {
+		reminderDays[bill.ID] = bill.ReminderDaysBefore
+	}
+
+	var events []entities.CalendarEvent
+	for _, occurrence := range billOccurrences(bills, today, from, to) {
+		amount := fmt.Sprintf("%.2f", occurrence.AmountMax)
+		if occurrence.AmountMin != occurrence.AmountMax {
+			amount = fmt.Sprintf("%.2f-%.2f", occurrence.AmountMin, occurrence.AmountMax)
+		}
+		if occurrence.Currency != "" {
+			amount += " " + occurrence.Currency
+		}
+
+		event := entities.CalendarEvent{
+			UID:     fmt.Sprintf("bill-%s-%s@personal-finance-tracker", occurrence.BillID.Hex(), occurrence.DueDate.Format("20060102")),
+			Summary: occurrence.Payee + " due (" + amount + ")",
+			Date:    occurrence.DueDate,
+		}
+
+		var details []string
+		if occurrence.Autopay {
+			details = append(details, "Paid automatically.")
+		}
+		switch occurrence.Status {
+		case entities.BillStatusPaid:
+			details = append(details, fmt.Sprintf("Paid %.2f on %s.", occurrence.Payment.Amount, occurrence.Payment.Date.Format("2006-01-02")))
+		case entities.BillStatusMissed:
+			details = append(details, "Missed.")
+		case entities.BillStatusUpcoming:
+			if !occurrence.Autopay {
+				event.AlarmDaysBefore = reminderDays[occurrence.BillID]
+			}
+		}
+		event.Description = strings.Join(details, " ")
+
+		events = append(events, event)
+	}
+	return events
+}
+
+// goalCalendarEvents lists the deadlines of goals that are not reached yet
+func goalCalendarEvents(goals []entities.Goal, today time.Time) []entities.CalendarEvent {
+	var events []entities.CalendarEvent
+	for _, goal := range goals {
+		saved := goal.CurrentAmount()
+		if goal.TargetDate == nil || saved >= goal.TargetAmount {
+			continue
+		}
+		deadline := startOfDay(*goal.TargetDate)
+
+		event := entities.CalendarEvent{
+			UID:         fmt.Sprintf("goal-%s@personal-finance-tracker", goal.ID.Hex()),
+			Summary:     "Goal deadline: " + goal.Name,
+			Description: strings.TrimSpace(fmt.Sprintf("%.2f of %.2f %s", roundCents(saved), goal.TargetAmount, goal.Currency)) + " saved.",
+			Date:        deadline,
+		}
+		if !deadline.Before(today) {
+			event.AlarmDaysBefore = goalAlarmDays
+		}
+		events = append(events, event)
+	}
+	return events
+}
@@ -0,0 +1,215 @@
+package usecase
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"personal-finance-tracker/domain/entities"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// shareEpsilon absorbs float noise when comparing share quantities
+const shareEpsilon = 1e-9
+
+// lotBook is the state of an account after replaying its events: the open
+// lots per symbol in acquisition order, realized gains and dividends
+type lotBook struct {
+	method    string
+	lots      map[string][]*entities.TaxLot
+	realized  []entities.RealizedGain
+	dividends []entities.InvestmentEvent
+}
+
+// sortEvents orders events the way they are replayed. Events on the same
+// day keep their insertion order, which ObjectIDs preserve.
+func sortEvents(events []entities.InvestmentEvent) {
+	sort.SliceStable(events, func(i, j int) bool {
+		if !events[i].Date.Equal(events[j].Date) {
+			return events[i].Date.Before(events[j].Date)
+		}
+		return bytes.Compare(events[i].ID[:], events[j].ID[:]) < 0
+	})
+}
+
+// replayEvents applies events up to and including until (zero means all)
+// and fails if a sale uses shares that weren't held at the time
+func replayEvents(events []entities.InvestmentEvent, method string, until time.Time) (*lotBook, error) {
+	book := &lotBook{
+		method: method,
+		lots:   map[string][]*entities.TaxLot{},
+	}
+
+	for _, event := range events {
+		if !until.IsZero() && event.Date.After(until) {
+			break
+		}
+
+		switch event.Type {
+		case entities.InvestmentEventBuy:
+			cost := event.Quantity*event.Price + event.Fees
+			book.lots[event.Symbol] = append(book.lots[event.Symbol], &entities.TaxLot{
+				LotID:        event.ID,
+				Symbol:       event.Symbol,
+				AcquiredAt:   event.Date,
+				Quantity:     event.Quantity,
+				CostPerShare: cost / event.Quantity,
+				CostBasis:    cost,
+			})
+		case entities.InvestmentEventSell:
+			if err := book.sell(event); err != nil {
+				return nil, err
+			}
+		case entities.InvestmentEventSplit:
+			for _, lot := range book.lots[event.Symbol] {
+				lot.Quantity *= event.SplitRatio
+				lot.CostPerShare /= event.SplitRatio
+			}
+		case entities.InvestmentEventDividend:
+			book.dividends = append(book.dividends, event)
+		}
+	}
+
+	return book, nil
+}
+
+func (b *lotBook) held(symbol string) float64 {
+	total := 0.0
+	for _, lot := range b.lots[symbol] {
+		total += lot.Quantity
+	}
+	return total
+}
+
+func (b *lotBook) sell(event entities.InvestmentEvent) error {
+	held := b.held(event.Symbol)
+	if event.Quantity > held+shareEpsilon {
+		return fmt.Errorf("cannot sell %g shares of %s on %s: only %g held",
+			event.Quantity, event.Symbol, event.Date.Format("2006-01-02"), held)
+	}
+	proceedsPerShare := (event.Quantity*event.Price - event.Fees) / event.Quantity
+
+	switch b.method {
+	case entities.CostBasisAverage:
+		b.sellAverage(event, held, proceedsPerShare)
+	case entities.CostBasisSpecificLot:
+		if err := b.sellSpecific(event, proceedsPerShare); err != nil {
+			return err
+		}
+	case entities.CostBasisLIFO:
+		lots := b.lots[event.Symbol]
+		remaining := event.Quantity
+		for i := len(lots) - 1; i >= 0 && remaining > shareEpsilon; i-- {
+			remaining -= b.consume(event, lots[i], remaining, proceedsPerShare)
+		}
+	default:
+		remaining := event.Quantity
+		for _, lot := range b.lots[event.Symbol] {
+			if remaining <= shareEpsilon {
+				break
+			}
+			remaining -= b.consume(event, lot, remaining, proceedsPerShare)
+		}
+	}
+
+	b.dropClosedLots(event.Symbol)
+	return nil
+}
+
+// consume takes up to quantity shares from lot and records the gain
+func (b *lotBook) consume(event entities.InvestmentEvent, lot *entities.TaxLot, quantity, proceedsPerShare float64) float64 {
+	taken := math.Min(quantity, lot.Quantity)
+	cost := taken * lot.CostPerShare
+	lot.Quantity -= taken
+	lot.CostBasis = lot.Quantity * lot.CostPerShare
+
+	b.realized = append(b.realized, entities.RealizedGain{
+		EventID:   event.ID,
+		Symbol:    event.Symbol,
+		Date:      event.Date,
+		Quantity:  taken,
+		Proceeds:  roundCents(taken * proceedsPerShare),
+		CostBasis: roundCents(cost),
+		Gain:      roundCents(taken*proceedsPerShare - cost),
+		LongTerm:  isLongTerm(lot.AcquiredAt, event.Date),
+	})
+	return taken
+}
+
+// sellAverage prices the sale at the average cost of all shares held and
+// shrinks every lot proportionally. The holding period follows the oldest lot.
+func (b *lotBook) sellAverage(event entities.InvestmentEvent, held, proceedsPerShare float64) {
+	lots := b.lots[event.Symbol]
+	totalCost := 0.0
+	for _, lot := range lots {
+		totalCost += lot.Quantity * lot.CostPerShare
+	}
+	cost := totalCost / held * event.Quantity
+
+	keep := 1 - event.Quantity/held
+	for _, lot := range lots {
+		lot.Quantity *= keep
+		lot.CostBasis = lot.Quantity * lot.CostPerShare
+	}
+
+	b.realized = append(b.realized, entities.RealizedGain{
+		EventID:   event.ID,
+		Symbol:    event.Symbol,
+		Date:      event.Date,
+		Quantity:  event.Quantity,
+		Proceeds:  roundCents(event.Quantity * proceedsPerShare),
+		CostBasis: roundCents(cost),
+		Gain:      roundCents(event.Quantity*proceedsPerShare - cost),
+		LongTerm:  isLongTerm(lots[0].AcquiredAt, event.Date),
+	})
+}
+
+func (b *lotBook) sellSpecific(event entities.InvestmentEvent, proceedsPerShare float64) error {
+	if len(event.Lots) == 0 {
+		return fmt.Errorf("sale of %s on %s must select lots for specific-lot cost basis",
+			event.Symbol, event.Date.Format("2006-01-02"))
+	}
+
+	byID := map[primitive.ObjectID]*entities.TaxLot{}
+	for _, lot := range b.lots[event.Symbol] {
+		byID[lot.LotID] = lot
+	}
+
+	selected := 0.0
+	for _, selection := range event.Lots {
+		lot, ok := byID[selection.LotID]
+		if !ok || selection.Quantity > lot.Quantity+shareEpsilon {
+			return fmt.Errorf("lot %s of %s doesn't hold %g shares on %s",
+				selection.LotID.Hex(), event.Symbol, selection.Quantity, event.Date.Format("2006-01-02"))
+		}
+		b.consume(event, lot, selection.Quantity, proceedsPerShare)
+		selected += selection.Quantity
+	}
+
+	if math.Abs(selected-event.Quantity) > shareEpsilon {
+		return fmt.Errorf("selected lots total %g shares but the sale is %g", selected, event.Quantity)
+	}
+	return nil
+}
+
+func (b *lotBook) dropClosedLots(symbol string) {
+	open := b.lots[symbol][:0]
+	for _, lot := range b.lots[symbol] {
+		if lot.Quantity > shareEpsilon {
+			open = append(open, lot)
+		}
+	}
+	if len(open) == 0 {
+		delete(b.lots, symbol)
+		return
+	}
+	b.lots[symbol] = open
+}
+
+// isLongTerm reports whether shares were held for more than a year
+func isLongTerm(acquired, sold time.Time) bool {
+	return sold.After(acquired.AddDate(1, 0, 0))
+}
@@ -0,0 +1,198 @@
+package usecase
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"personal-finance-tracker/domain/entities"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func costBasisDate(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func buyEvent(on time.Time, quantity, price, fees float64) entities.InvestmentEvent {
+	return entities.InvestmentEvent{ID: primitive.NewObjectID(), Type: entities.InvestmentEventBuy, Symbol: "ACME", Date: on, Quantity: quantity, Price: price, Fees: fees}
+}
+
+func sellEvent(on time.Time, quantity, price, fees float64, lots ...entities.LotSelection) entities.InvestmentEvent {
+	return entities.InvestmentEvent{ID: primitive.NewObjectID(), Type: entities.InvestmentEventSell, Symbol: "ACME", Date: on, Quantity: quantity, Price: price, Fees: fees, Lots: lots}
+}
+
+func TestReplayEventsCostBasis(t *testing.T) {
+	older := buyEvent(costBasisDate(2022, 1, 10), 10, 100, 0)
+	newer := buyEvent(costBasisDate(2023, 6, 1), 10, 200, 0)
+	saleDate := costBasisDate(2023, 9, 1)
+
+	type gain struct {
+		cost, gain float64
+		longTerm   bool
+	}
+	tests := []struct {
+		name          string
+		method        string
+		sale          entities.InvestmentEvent
+		wantGains     []gain
+		wantHeldCost  float64
+		wantLotsAfter int
+	}{
+		{
+			name:          "fifo sells the oldest lot first",
+			method:        entities.CostBasisFIFO,
+			sale:          sellEvent(saleDate, 15, 300, 0),
+			wantGains:     []gain{{1000, 2000, true}, {1000, 500, false}},
+			wantHeldCost:  1000,
+			wantLotsAfter: 1,
+		},
+		{
+			name:          "lifo sells the newest lot first",
+			method:        entities.CostBasisLIFO,
+			sale:          sellEvent(saleDate, 15, 300, 0),
+			wantGains:     []gain{{2000, 1000, false}, {500, 1000, true}},
+			wantHeldCost:  500,
+			wantLotsAfter: 1,
+		},
+		{
+			name:          "average cost shrinks every lot",
+			method:        entities.CostBasisAverage,
+			sale:          sellEvent(saleDate, 15, 300, 0),
+			wantGains:     []gain{{2250, 2250, true}},
+			wantHeldCost:  750,
+			wantLotsAfter: 2,
+		},
+		{
+			name:   "specific lots in the order selected",
+			method: entities.CostBasisSpecificLot,
+			sale: sellEvent(saleDate, 15, 300, 0,
+				entities.LotSelection{LotID: newer.ID, Quantity: 5},
+				entities.LotSelection{LotID: older.ID, Quantity: 10}),
+			wantGains:     []gain{{1000, 500, false}, {1000, 2000, true}},
+			wantHeldCost:  1000,
+			wantLotsAfter: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			book, err := replayEvents([]entities.InvestmentEvent{older, newer, tt.sale}, tt.method, time.Time{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(book.realized) != len(tt.wantGains) {
+				t.Fatalf("realized %d gains, want %d: %+v", len(book.realized), len(tt.wantGains), book.realized)
+			}
+			for i, want := range tt.wantGains {
+				got := book.realized[i]
+				if got.CostBasis != want.cost || got.Gain != want.gain || got.LongTerm != want.longTerm {
+					t.Errorf("gain %d = cost %v gain %v long %v, want %+v", i, got.CostBasis, got.Gain, got.LongTerm, want)
+				}
+			}
+
+			heldCost := 0.0
+			for _, lot := range book.lots["ACME"] {
+				heldCost += lot.CostBasis
+			}
+			if math.Abs(heldCost-tt.wantHeldCost) > 1e-6 {
+				t.Errorf("remaining cost basis = %v, want %v", heldCost, tt.wantHeldCost)
+			}
+			if len(book.lots["ACME"]) != tt.wantLotsAfter {
+				t.Errorf("open lots = %d, want %d", len(book.lots["ACME"]), tt.wantLotsAfter)
+			}
+			if held := book.held("ACME"); math.Abs(held-5) > shareEpsilon {
+				t.Errorf("held = %v, want 5", held)
+			}
+		})
+	}
+}
+
+func TestReplayEventsFeesAndSplits(t *testing.T) {
+	split := entities.InvestmentEvent{ID: primitive.NewObjectID(), Type: entities.InvestmentEventSplit, Symbol: "ACME", Date: costBasisDate(2023, 2, 1), SplitRatio: 2}
+
+	tests := []struct {
+		name     string
+		events   []entities.InvestmentEvent
+		wantGain float64
+	}{
+		{
+			name:     "fees raise the cost and lower the proceeds",
+			events:   []entities.InvestmentEvent{buyEvent(costBasisDate(2023, 1, 1), 10, 100, 10), sellEvent(costBasisDate(2023, 3, 1), 10, 120, 20)},
+			wantGain: 170,
+		},
+		{
+			name:     "split doubles the shares and halves their cost",
+			events:   []entities.InvestmentEvent{buyEvent(costBasisDate(2023, 1, 1), 10, 100, 0), split, sellEvent(costBasisDate(2023, 3, 1), 20, 60, 0)},
+			wantGain: 200,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			book, err := replayEvents(tt.events, entities.CostBasisFIFO, time.Time{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(book.realized) != 1 || book.realized[0].Gain != tt.wantGain {
+				t.Errorf("realized = %+v, want one gain of %v", book.realized, tt.wantGain)
+			}
+			if len(book.lots) != 0 {
+				t.Errorf("expected no open lots, got %+v", book.lots)
+			}
+		})
+	}
+}
+
+func TestReplayEventsErrors(t *testing.T) {
+	lot := buyEvent(costBasisDate(2023, 1, 1), 10, 100, 0)
+
+	tests := []struct {
+		name   string
+		method string
+		sale   entities.InvestmentEvent
+	}{
+		{"selling more than held", entities.CostBasisFIFO, sellEvent(costBasisDate(2023, 2, 1), 11, 100, 0)},
+		{"specific lot sale without lots", entities.CostBasisSpecificLot, sellEvent(costBasisDate(2023, 2, 1), 5, 100, 0)},
+		{"specific lots not adding up to the sale", entities.CostBasisSpecificLot, sellEvent(costBasisDate(2023, 2, 1), 5, 100, 0, entities.LotSelection{LotID: lot.ID, Quantity: 3})},
+		{"unknown lot", entities.CostBasisSpecificLot, sellEvent(costBasisDate(2023, 2, 1), 5, 100, 0, entities.LotSelection{LotID: primitive.NewObjectID(), Quantity: 5})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := replayEvents([]entities.InvestmentEvent{lot, tt.sale}, tt.method, time.Time{}); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
+
+func TestReplayEventsUntil(t *testing.T) {
+	events := []entities.InvestmentEvent{buyEvent(costBasisDate(2023, 1, 1), 10, 100, 0), sellEvent(costBasisDate(2023, 3, 1), 4, 120, 0)}
+
+	book, err := replayEvents(events, entities.CostBasisFIFO, costBasisDate(2023, 2, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if held := book.held("ACME"); held != 10 {
+		t.Errorf("held before the sale = %v, want 10", held)
+	}
+}
+
+func TestIsLongTerm(t *testing.T) {
+	acquired := costBasisDate(2023, 3, 15)
+	tests := []struct {
+		sold time.Time
+		want bool
+	}{
+		{costBasisDate(2024, 3, 15), false},
+		{costBasisDate(2024, 3, 16), true},
+		{costBasisDate(2023, 9, 1), false},
+	}
+
+	for _, tt := range tests {
+		if got := isLongTerm(acquired, tt.sold); got != tt.want {
+			t.Errorf("isLongTerm(%s) = %v, want %v", tt.sold.Format("2006-01-02"), got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"personal-finance-tracker/domain/entities"
+	repoInterface "personal-finance-tracker/domain/interface"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var debtTypes = map[string]bool{
+	entities.DebtTypeMortgage:     true,
+	entities.DebtTypeCarLoan:      true,
+	entities.DebtTypeCreditCard:   true,
+	entities.DebtTypeStudentLoan:  true,
+	entities.DebtTypePersonalLoan: true,
+	entities.DebtTypeOther:        true,
+}
+
+type DebtUsecase struct {
+	debtRepo          repoInterface.DebtRepository
+	attachmentUsecase *AttachmentUsecase
+}
+
+func NewDebtUsecase(debtRepo repoInterface.DebtRepository, attachmentUsecase *AttachmentUsecase) *DebtUsecase {
+	return &DebtUsecase{
+		debtRepo:          debtRepo,
+		attachmentUsecase: attachmentUsecase,
+	}
+}
+
+// CreateDebt saves a new debt; without a current balance it hasn't been paid
+// down yet and starts at the principal
+func (u *DebtUsecase) CreateDebt(userID string, debt *entities.Debt, currentBalance *float64) (*entities.Debt, error) {
+	ownerID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, errors.New("invalid user id")
+	}
+	debt.CurrentBalance = debt.Principal
+	if currentBalance != nil {
+		debt.CurrentBalance = *currentBalance
+	}
+	if err := validateDebt(debt); err != nil {
+		return nil, err
+	}
+
+	debt.ID = primitive.NilObjectID
+	debt.UserID = ownerID
+	debt.ExtraPayments = []entities.ExtraPayment{}
+	debt.CreatedAt = time.Now()
+	debt.UpdatedAt = time.Now()
+
+	createdDebt, err := u.debtRepo.CreateDebt(debt)
+	if err != nil {
+		return nil, errors.New("Failed to create debt: " + err.Error())
+	}
+	return createdDebt, nil
+}
+
+func (u *DebtUsecase) GetDebt(userID, id string) (*entities.Debt, error) {
+	return u.debtRepo.GetDebtByID(id, userID)
+}
+
+func (u *DebtUsecase) ListDebts(userID string, page entities.PageRequest) (*entities.Page[entities.Debt], error) {
+	debts, err := u.debtRepo.ListDebts(userID, page)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "invalid") {
+			return nil, err
+		}
+		return nil, errors.New("Database error: " + err.Error())
+	}
+	return debts, nil
+}
+
+// UpdateDebt replaces the editable fields; the current balance is only changed
+// when one is given
+func (u *DebtUsecase) UpdateDebt(userID, id string, input *entities.Debt, currentBalance *float64) (*entities.Debt, error) {
+	debt, err := u.debtRepo.GetDebtByID(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	input.CurrentBalance = debt.CurrentBalance
+	if currentBalance != nil {
+		input.CurrentBalance = *currentBalance
+	}
+	if err := validateDebt(input); err != nil {
+		return nil, err
+	}
+
+	debt.Name = input.Name
+	debt.Type = input.Type
+	debt.Lender = input.Lender
+	debt.Currency = input.Currency
+	debt.Principal = input.Principal
+	debt.CurrentBalance = input.CurrentBalance
+	debt.APR = input.APR
+	debt.Compounding = input.Compounding
+	debt.PaymentFrequency = input.PaymentFrequency
+	debt.PaymentAmount = input.PaymentAmount
+	debt.TermMonths = input.TermMonths
+	debt.StartDate = input.StartDate
+	debt.UpdatedAt = time.Now()
+
+	return u.debtRepo.UpdateDebt(debt)
+}
+
+func (u *DebtUsecase) DeleteDebt(userID, id string) error {
+	if err := u.debtRepo.DeleteDebt(id, userID); err != nil {
+		return err
+	}
+	return u.attachmentUsecase.DeleteParentAttachments(userID, entities.AttachmentParentDebt, id)
+}
+
+func (u *DebtUsecase) AddExtraPayment(userID, debtID string, payment entities.ExtraPayment) (*entities.Debt, error) {
+	if payment.Amount <= 0 {
+		return nil, errors.New("extra payment amount must be positive")
+	}
+	if payment.Date.IsZero() {
+		payment.Date = time.Now()
+	}
+
+	payment.ID = primitive.NewObjectID()
+	return u.debtRepo.AddExtraPayment(debtID, userID, payment)
+}
+
+func (u *DebtUsecase) RemoveExtraPayment(userID, debtID, paymentID string) (*entities.Debt, error) {
+	return u.debtRepo.RemoveExtraPayment(debtID, userID, paymentID)
+}
+
+// GetAmortization generates the payment table of one debt
+func (u *DebtUsecase) GetAmortization(userID, id string) (*entities.AmortizationSchedule, error) {
+	debt, err := u.debtRepo.GetDebtByID(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	return buildAmortization(debt)
+}
+
+// SimulatePayoff compares payoff strategies across all of the user's debts.
+// An empty strategy runs both snowball and avalanche.
+func (u *DebtUsecase) SimulatePayoff(userID, strategy string, extraBudget float64) ([]*entities.PayoffPlan, error) {
+	strategies := []string{entities.PayoffStrategySnowball, entities.PayoffStrategyAvalanche}
+	switch strategy {
+	case "":
+	case entities.PayoffStrategySnowball, entities.PayoffStrategyAvalanche:
+		strategies = []string{strategy}
+	default:
+		return nil, errors.New("invalid strategy: must be snowball or avalanche")
+	}
+	if extraBudget < 0 {
+		return nil, errors.New("extra budget cannot be negative")
+	}
+
+	debts, err := u.debtRepo.GetAllDebts(userID)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "invalid") {
+			return nil, err
+		}
+		return nil, errors.New("Database error: " + err.Error())
+	}
+
+	plans := []*entities.PayoffPlan{}
+	for _, name := range strategies {
+		// Each simulation gets its own copy since balances are mutated
+		plan, err := simulatePayoff(append([]entities.Debt(nil), debts...), name, extraBudget, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		plans = append(plans, plan)
+	}
+	return plans, nil
+}
+
+func validateDebt(debt *entities.Debt) error {
+	debt.Name = strings.TrimSpace(debt.Name)
+	if debt.Name == "" {
+		return errors.New("debt name is required")
+	}
+	if debt.Type == "" {
+		debt.Type = entities.DebtTypeOther
+	}
+	if !debtTypes[debt.Type] {
+		return errors.New("invalid debt type")
+	}
+	debt.Currency = strings.ToUpper(debt.Currency)
+	if debt.Currency != "" && !currencyCodeRegex.MatchString(debt.Currency) {
+		return errors.New("invalid currency code")
+	}
+	if debt.Principal <= 0 {
+		return errors.New("principal must be positive")
+	}
+	if debt.CurrentBalance < 0 {
+		return errors.New("current balance cannot be negative")
+	}
+	if debt.APR < 0 || debt.APR > 100 {
+		return errors.New("apr must be between 0 and 100")
+	}
+
+	if debt.Compounding == "" {
+		debt.Compounding = entities.FrequencyMonthly
+	}
+	if _, ok := periodsPerYear[debt.Compounding]; !ok {
+		return errors.New("invalid compounding frequency")
+	}
+	if debt.PaymentFrequency == "" {
+		debt.PaymentFrequency = entities.FrequencyMonthly
+	}
+	switch debt.PaymentFrequency {
+	case entities.FrequencyWeekly, entities.FrequencyBiweekly, entities.FrequencyMonthly:
+	default:
+		return errors.New("invalid payment frequency: must be weekly, biweekly or monthly")
+	}
+
+	if debt.PaymentAmount < 0 || debt.TermMonths < 0 {
+		return errors.New("payment amount and term cannot be negative")
+	}
+	if debt.PaymentAmount == 0 && debt.TermMonths == 0 {
+		return errors.New("payment amount or term is required")
+	}
+	if debt.StartDate.IsZero() {
+		debt.StartDate = time.Now()
+	}
+	return nil
+}
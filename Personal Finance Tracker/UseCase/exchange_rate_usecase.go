@@ -0,0 +1,148 @@
+package usecase
+
+import (
+	"errors"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"personal-finance-tracker/Infrastructure/service"
+	"personal-finance-tracker/domain/entities"
+	repoInterface "personal-finance-tracker/domain/interface"
+)
+
+// maxRateAge bounds how far back a conversion may fall back when no rate
+// was published on the requested date (weekends, bank holidays)
+const maxRateAge = 7 * 24 * time.Hour
+
+var currencyCodeRegex = regexp.MustCompile(`^[A-Z]{3}$`)
+
+type ExchangeRateUsecase struct {
+	rateRepo repoInterface.ExchangeRateRepository
+	userRepo repoInterface.UserRepository
+}
+
+func NewExchangeRateUsecase(rateRepo repoInterface.ExchangeRateRepository, userRepo repoInterface.UserRepository) *ExchangeRateUsecase {
+	return &ExchangeRateUsecase{
+		rateRepo: rateRepo,
+		userRepo: userRepo,
+	}
+}
+
+// ImportRates parses an ECB csv or xml file and stores its rates
+func (u *ExchangeRateUsecase) ImportRates(format string, r io.Reader) (int64, error) {
+	var rates []entities.ExchangeRate
+	var err error
+
+	switch strings.ToLower(format) {
+	case "csv":
+		rates, err = services.ParseECBCSV(r)
+	case "xml":
+		rates, err = services.ParseECBXML(r)
+	default:
+		return 0, errors.New("invalid format: must be csv or xml")
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := u.rateRepo.UpsertRates(rates)
+	if err != nil {
+		return 0, errors.New("Database error: " + err.Error())
+	}
+	return count, nil
+}
+
+func (u *ExchangeRateUsecase) ListRates(quote string, from, to time.Time, page entities.PageRequest) (*entities.Page[entities.ExchangeRate], error) {
+	quote = strings.ToUpper(quote)
+	if quote != "" && !currencyCodeRegex.MatchString(quote) {
+		return nil, errors.New("invalid currency code")
+	}
+
+	rates, err := u.rateRepo.ListRates(entities.ECBBaseCurrency, quote, from, to, page)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "invalid") {
+			return nil, err
+		}
+		return nil, errors.New("Database error: " + err.Error())
+	}
+	return rates, nil
+}
+
+// Convert converts amount on the given date. When to is empty the
+// user's base currency is used.
+func (u *ExchangeRateUsecase) Convert(userID string, amount float64, from, to string, date time.Time) (*entities.Conversion, error) {
+	if to == "" {
+		user, err := u.userRepo.GetUserByID(userID)
+		if err != nil {
+			return nil, err
+		}
+		to = user.Currency
+	}
+	if to == "" {
+		return nil, errors.New("invalid target currency: none given and user has no base currency")
+	}
+
+	from = strings.ToUpper(from)
+	to = strings.ToUpper(to)
+	rate, err := u.Rate(from, to, date)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.Conversion{
+		Amount:    amount,
+		From:      from,
+		To:        to,
+		Date:      date,
+		Rate:      rate,
+		Converted: amount * rate,
+	}, nil
+}
+
+// ConvertAmount converts amount using the rates in effect on date
+func (u *ExchangeRateUsecase) ConvertAmount(amount float64, from, to string, date time.Time) (float64, error) {
+	rate, err := u.Rate(strings.ToUpper(from), strings.ToUpper(to), date)
+	if err != nil {
+		return 0, err
+	}
+	return amount * rate, nil
+}
+
+// Rate returns how many units of to one unit of from buys on date.
+// All stored rates share the ECB base, so other pairs are cross rates.
+func (u *ExchangeRateUsecase) Rate(from, to string, date time.Time) (float64, error) {
+	if !currencyCodeRegex.MatchString(from) || !currencyCodeRegex.MatchString(to) {
+		return 0, errors.New("invalid currency code")
+	}
+	if from == to {
+		return 1, nil
+	}
+
+	fromRate, err := u.baseRate(from, date)
+	if err != nil {
+		return 0, err
+	}
+	toRate, err := u.baseRate(to, date)
+	if err != nil {
+		return 0, err
+	}
+	return toRate / fromRate, nil
+}
+
+// baseRate returns the rate of the ECB base currency in currency
+func (u *ExchangeRateUsecase) baseRate(currency string, date time.Time) (float64, error) {
+	if currency == entities.ECBBaseCurrency {
+		return 1, nil
+	}
+
+	rate, err := u.rateRepo.GetRateOnOrBefore(entities.ECBBaseCurrency, currency, date, maxRateAge)
+	if err != nil {
+		if err.Error() == "exchange rate not found" {
+			return 0, errors.New("exchange rate for " + currency + " on " + date.Format("2006-01-02") + " not found")
+		}
+		return 0, errors.New("Database error: " + err.Error())
+	}
+	return rate.Rate, nil
+}
@@ -0,0 +1,335 @@
+package usecase
+
+import (
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"personal-finance-tracker/Infrastructure/utils"
+	"personal-finance-tracker/domain/entities"
+	repoInterface "personal-finance-tracker/domain/interface"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ExpenseGroupUsecase struct {
+	groupRepo   repoInterface.ExpenseGroupRepository
+	expenseRepo repoInterface.GroupExpenseRepository
+	userRepo    repoInterface.UserRepository
+}
+
+func NewExpenseGroupUsecase(groupRepo repoInterface.ExpenseGroupRepository, expenseRepo repoInterface.GroupExpenseRepository, userRepo repoInterface.UserRepository) *ExpenseGroupUsecase {
+	return &ExpenseGroupUsecase{
+		groupRepo:   groupRepo,
+		expenseRepo: expenseRepo,
+		userRepo:    userRepo,
+	}
+}
+
+// getGroup returns the group if the user is one of its registered
+// participants; everyone else gets "group not found"
+func (u *ExpenseGroupUsecase) getGroup(userID, groupID string) (*entities.ExpenseGroup, error) {
+	memberID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, errors.New("invalid user id")
+	}
+	group, err := u.groupRepo.GetGroupByID(groupID)
+	if err != nil {
+		return nil, err
+	}
+	if group.ParticipantForUser(memberID) == nil {
+		return nil, errors.New("group not found")
+	}
+	return group, nil
+}
+
+// CreateGroup starts a group with the creator as its first participant
+func (u *ExpenseGroupUsecase) CreateGroup(userID string, group *entities.ExpenseGroup) (*entities.ExpenseGroup, error) {
+	user, err := u.userRepo.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateExpenseGroup(group); err != nil {
+		return nil, err
+	}
+	if group.Currency == "" {
+		group.Currency = user.Currency
+	}
+
+	name := user.Name
+	if name == "" {
+		name = user.Email
+	}
+	group.ID = primitive.NilObjectID
+	group.CreatedBy = user.ID
+	group.Participants = []entities.Participant{{
+		ID:     primitive.NewObjectID(),
+		Name:   name,
+		Email:  user.Email,
+		UserID: &user.ID,
+	}}
+	group.CreatedAt = time.Now()
+	group.UpdatedAt = time.Now()
+
+	createdGroup, err := u.groupRepo.CreateGroup(group)
+	if err != nil {
+		return nil, errors.New("Failed to create group: " + err.Error())
+	}
+	return createdGroup, nil
+}
+
+func (u *ExpenseGroupUsecase) GetGroup(userID, id string) (*entities.ExpenseGroup, error) {
+	return u.getGroup(userID, id)
+}
+
+func (u *ExpenseGroupUsecase) ListGroups(userID string, page entities.PageRequest) (*entities.Page[entities.ExpenseGroup], error) {
+	groups, err := u.groupRepo.ListGroups(userID, page)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "invalid") {
+			return nil, err
+		}
+		return nil, errors.New("Database error: " + err.Error())
+	}
+	return groups, nil
+}
+
+func (u *ExpenseGroupUsecase) UpdateGroup(userID, id string, input *entities.ExpenseGroup) (*entities.ExpenseGroup, error) {
+	group, err := u.getGroup(userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateExpenseGroup(input); err != nil {
+		return nil, err
+	}
+
+	group.Name = input.Name
+	if input.Currency != "" {
+		group.Currency = input.Currency
+	}
+	group.UpdatedAt = time.Now()
+	return u.groupRepo.UpdateGroup(group)
+}
+
+// DeleteGroup removes the group with all its expenses; only its creator
+// may do so
+func (u *ExpenseGroupUsecase) DeleteGroup(userID, id string) error {
+	group, err := u.getGroup(userID, id)
+	if err != nil {
+		return err
+	}
+	if group.CreatedBy.Hex() != userID {
+		return errors.New("forbidden: only the creator can delete the group")
+	}
+
+	if err := u.groupRepo.DeleteGroup(id); err != nil {
+		return err
+	}
+	if err := u.expenseRepo.DeleteExpensesByGroup(id); err != nil {
+		log.Printf("⚠️ Failed to delete expenses of group %s: %v", id, err)
+	}
+	return nil
+}
+
+// AddParticipant adds a person by name and optional email. An email that
+// belongs to a registered user links the participant to that user, who can
+// then see the group.
+func (u *ExpenseGroupUsecase) AddParticipant(userID, groupID string, participant entities.Participant) (*entities.ExpenseGroup, error) {
+	group, err := u.getGroup(userID, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	participant.Name = strings.TrimSpace(participant.Name)
+	participant.Email = strings.ToLower(strings.TrimSpace(participant.Email))
+	participant.UserID = nil
+	if participant.Email != "" {
+		if err := utils.IsEmailValid(participant.Email); err != nil {
+			return nil, err
+		}
+		for _, existing := range group.Participants {
+			if existing.Email == participant.Email {
+				return nil, errors.New("a participant with this email is already in the group")
+			}
+		}
+		if user, err := u.userRepo.GetUserByEmail(participant.Email); err == nil {
+			participant.UserID = &user.ID
+			if participant.Name == "" {
+				participant.Name = user.Name
+			}
+		}
+	}
+	if participant.Name == "" {
+		return nil, errors.New("name is required")
+	}
+
+	participant.ID = primitive.NewObjectID()
+	return u.groupRepo.AddParticipant(groupID, participant)
+}
+
+// RemoveParticipant only removes people who appear in no expense, so past
+// balances stay intact
+func (u *ExpenseGroupUsecase) RemoveParticipant(userID, groupID, participantID string) (*entities.ExpenseGroup, error) {
+	group, err := u.getGroup(userID, groupID)
+	if err != nil {
+		return nil, err
+	}
+	objectID, err := primitive.ObjectIDFromHex(participantID)
+	if err != nil {
+		return nil, errors.New("invalid participant id")
+	}
+	participant := group.Participant(objectID)
+	if participant == nil {
+		return nil, errors.New("participant not found")
+	}
+	// The creator alone can delete the group, so they have to stay in it
+	if participant.UserID != nil && *participant.UserID == group.CreatedBy {
+		return nil, errors.New("forbidden: the group's creator cannot be removed")
+	}
+	if len(group.Participants) == 1 {
+		return nil, errors.New("cannot remove the last participant: delete the group instead")
+	}
+
+	expenses, err := u.getExpenses(groupID)
+	if err != nil {
+		return nil, err
+	}
+	for _, expense := range expenses {
+		if expense.PaidBy == objectID {
+			return nil, errors.New("participant has expenses in this group")
+		}
+		for _, split := range expense.Splits {
+			if split.ParticipantID == objectID {
+				return nil, errors.New("participant has expenses in this group")
+			}
+		}
+	}
+
+	return u.groupRepo.RemoveParticipant(groupID, participantID)
+}
+
+func (u *ExpenseGroupUsecase) AddExpense(userID, groupID string, expense *entities.GroupExpense) (*entities.GroupExpense, error) {
+	group, err := u.getGroup(userID, groupID)
+	if err != nil {
+		return nil, err
+	}
+	expense.Description = strings.TrimSpace(expense.Description)
+	if expense.Description == "" {
+		return nil, errors.New("description is required")
+	}
+	if expense.Amount <= 0 {
+		return nil, errors.New("amount must be positive")
+	}
+	if group.Participant(expense.PaidBy) == nil {
+		return nil, errors.New("invalid paid_by: not a participant of the group")
+	}
+	if err := splitExpense(expense, group); err != nil {
+		return nil, err
+	}
+
+	expense.Kind = entities.GroupExpenseKindExpense
+	return u.createExpense(userID, group, expense)
+}
+
+// RecordSettlement records a payment from one participant to another,
+// reducing what the payer owes the recipient
+func (u *ExpenseGroupUsecase) RecordSettlement(userID, groupID string, from, to primitive.ObjectID, amount float64, date time.Time) (*entities.GroupExpense, error) {
+	group, err := u.getGroup(userID, groupID)
+	if err != nil {
+		return nil, err
+	}
+	if amount <= 0 {
+		return nil, errors.New("amount must be positive")
+	}
+	payer, recipient := group.Participant(from), group.Participant(to)
+	if payer == nil || recipient == nil {
+		return nil, errors.New("invalid settlement: from and to must be participants of the group")
+	}
+	if from == to {
+		return nil, errors.New("invalid settlement: from and to must differ")
+	}
+
+	settlement := &entities.GroupExpense{
+		Kind:        entities.GroupExpenseKindSettlement,
+		Description: payer.Name + " paid " + recipient.Name,
+		Amount:      amount,
+		Date:        date,
+		PaidBy:      from,
+		SplitMethod: entities.SplitExact,
+		Splits:      []entities.ExpenseSplit{{ParticipantID: to, Value: amount, Amount: roundCents(amount)}},
+	}
+	return u.createExpense(userID, group, settlement)
+}
+
+func (u *ExpenseGroupUsecase) ListExpenses(userID, groupID string, page entities.PageRequest) (*entities.Page[entities.GroupExpense], error) {
+	if _, err := u.getGroup(userID, groupID); err != nil {
+		return nil, err
+	}
+	expenses, err := u.expenseRepo.ListExpenses(groupID, page)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "invalid") {
+			return nil, err
+		}
+		return nil, errors.New("Database error: " + err.Error())
+	}
+	return expenses, nil
+}
+
+func (u *ExpenseGroupUsecase) DeleteExpense(userID, groupID, expenseID string) error {
+	if _, err := u.getGroup(userID, groupID); err != nil {
+		return err
+	}
+	return u.expenseRepo.DeleteExpense(expenseID, groupID)
+}
+
+// GetBalances reports each participant's net position and the fewest
+// payments that would settle the group
+func (u *ExpenseGroupUsecase) GetBalances(userID, groupID string) (*entities.GroupBalances, error) {
+	group, err := u.getGroup(userID, groupID)
+	if err != nil {
+		return nil, err
+	}
+	expenses, err := u.getExpenses(groupID)
+	if err != nil {
+		return nil, err
+	}
+	return groupBalances(group, expenses), nil
+}
+
+func (u *ExpenseGroupUsecase) createExpense(userID string, group *entities.ExpenseGroup, expense *entities.GroupExpense) (*entities.GroupExpense, error) {
+	creatorID, _ := primitive.ObjectIDFromHex(userID)
+	if expense.Date.IsZero() {
+		expense.Date = time.Now()
+	}
+	expense.ID = primitive.NilObjectID
+	expense.GroupID = group.ID
+	expense.Amount = roundCents(expense.Amount)
+	expense.CreatedBy = creatorID
+	expense.CreatedAt = time.Now()
+
+	createdExpense, err := u.expenseRepo.CreateExpense(expense)
+	if err != nil {
+		return nil, errors.New("Failed to create expense: " + err.Error())
+	}
+	return createdExpense, nil
+}
+
+func (u *ExpenseGroupUsecase) getExpenses(groupID string) ([]entities.GroupExpense, error) {
+	expenses, err := u.expenseRepo.GetAllExpenses(groupID)
+	if err != nil {
+		return nil, errors.New("Database error: " + err.Error())
+	}
+	return expenses, nil
+}
+
+func validateExpenseGroup(group *entities.ExpenseGroup) error {
+	group.Name = strings.TrimSpace(group.Name)
+	if group.Name == "" {
+		return errors.New("name is required")
+	}
+	group.Currency = strings.ToUpper(group.Currency)
+	if group.Currency != "" && !currencyCodeRegex.MatchString(group.Currency) {
+		return errors.New("invalid currency code")
+	}
+	return nil
+}
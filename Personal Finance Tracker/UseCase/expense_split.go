@@ -0,0 +1,280 @@
+package usecase
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	"personal-finance-tracker/domain/entities"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// maxExactSettleUp is the most non-zero balances settled optimally; the
+// search is exponential, so larger groups use the greedy plan
+const maxExactSettleUp = 16
+
+// Splits and balances are computed in whole cents so that the parts of an
+// expense always add up to its amount
+func toCents(amount float64) int64 {
+	return int64(math.Round(amount * 100))
+}
+
+func fromCents(cents int64) float64 {
+	return float64(cents) / 100
+}
+
+// splitExpense fills in what each participant owes. Equal splits without
+// any splits listed are shared by the whole group.
+func splitExpense(expense *entities.GroupExpense, group *entities.ExpenseGroup) error {
+	if expense.SplitMethod == "" {
+		expense.SplitMethod = entities.SplitEqual
+	}
+	if len(expense.Splits) == 0 {
+		if expense.SplitMethod != entities.SplitEqual {
+			return errors.New("splits are required for " + expense.SplitMethod + " splits")
+		}
+		for _, participant := range group.Participants {
+			expense.Splits = append(expense.Splits, entities.ExpenseSplit{ParticipantID: participant.ID})
+		}
+	}
+
+	seen := map[primitive.ObjectID]bool{}
+	for _, split := range expense.Splits {
+		if group.Participant(split.ParticipantID) == nil {
+			return errors.New("invalid split: participant " + split.ParticipantID.Hex() + " is not in the group")
+		}
+		if seen[split.ParticipantID] {
+			return errors.New("invalid split: participant listed twice")
+		}
+		seen[split.ParticipantID] = true
+		if split.Value < 0 {
+			return errors.New("invalid split: values cannot be negative")
+		}
+	}
+
+	total := toCents(expense.Amount)
+	weights := make([]float64, len(expense.Splits))
+	sum := 0.0
+	for i, split := range expense.Splits {
+		weights[i] = split.Value
+		sum += split.Value
+	}
+
+	var amounts []int64
+	switch expense.SplitMethod {
+	case entities.SplitEqual:
+		for i := range weights {
+			weights[i] = 1
+		}
+		amounts = allocateCents(total, weights)
+	case entities.SplitExact:
+		amounts = make([]int64, len(weights))
+		var allocated int64
+		for i, weight := range weights {
+			amounts[i] = toCents(weight)
+			allocated += amounts[i]
+		}
+		if allocated != total {
+			return errors.New("invalid split: exact amounts must add up to the expense amount")
+		}
+	case entities.SplitPercentage:
+		if math.Abs(sum-100) > 0.001 {
+			return errors.New("invalid split: percentages must add up to 100")
+		}
+		amounts = allocateCents(total, weights)
+	case entities.SplitShares:
+		if sum <= 0 {
+			return errors.New("invalid split: shares must add up to more than zero")
+		}
+		amounts = allocateCents(total, weights)
+	default:
+		return errors.New("invalid split method: must be equal, exact, percentage or shares")
+	}
+
+	for i := range expense.Splits {
+		expense.Splits[i].Amount = fromCents(amounts[i])
+	}
+	return nil
+}
+
+// allocateCents divides total in proportion to the weights, handing the
+// cents lost to rounding to the largest remainders first
+func allocateCents(total int64, weights []float64) []int64 {
+	sum := 0.0
+	for _, weight := range weights {
+		sum += weight
+	}
+
+	amounts := make([]int64, len(weights))
+	remainders := make([]float64, len(weights))
+	var allocated int64
+	for i, weight := range weights {
+		exact := float64(total) * weight / sum
+		amounts[i] = int64(math.Floor(exact))
+		remainders[i] = exact - float64(amounts[i])
+		allocated += amounts[i]
+	}
+
+	order := make([]int, len(weights))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return remainders[order[a]] > remainders[order[b]]
+	})
+	for i := 0; allocated < total; i++ {
+		amounts[order[i%len(order)]]++
+		allocated++
+	}
+	return amounts
+}
+
+// groupBalances nets what each participant paid against what they owe
+func groupBalances(group *entities.ExpenseGroup, expenses []entities.GroupExpense) *entities.GroupBalances {
+	paid := map[primitive.ObjectID]int64{}
+	owed := map[primitive.ObjectID]int64{}
+	for _, expense := range expenses {
+		paid[expense.PaidBy] += toCents(expense.Amount)
+		for _, split := range expense.Splits {
+			owed[split.ParticipantID] += toCents(split.Amount)
+		}
+	}
+
+	result := &entities.GroupBalances{
+		Currency: group.Currency,
+		Balances: []entities.ParticipantBalance{},
+		SettleUp: []entities.SuggestedPayment{},
+	}
+	nets := make([]int64, len(group.Participants))
+	for i, participant := range group.Participants {
+		nets[i] = paid[participant.ID] - owed[participant.ID]
+		result.Balances = append(result.Balances, entities.ParticipantBalance{
+			ParticipantID: participant.ID,
+			Name:          participant.Name,
+			Paid:          fromCents(paid[participant.ID]),
+			Owed:          fromCents(owed[participant.ID]),
+			Net:           fromCents(nets[i]),
+		})
+	}
+
+	for _, payment := range settleUp(nets) {
+		from, to := group.Participants[payment.from], group.Participants[payment.to]
+		result.SettleUp = append(result.SettleUp, entities.SuggestedPayment{
+			From:     from.ID,
+			FromName: from.Name,
+			To:       to.ID,
+			ToName:   to.Name,
+			Amount:   fromCents(payment.cents),
+		})
+	}
+	return result
+}
+
+type settlePayment struct {
+	from, to int
+	cents    int64
+}
+
+// settleUp returns the fewest payments that bring every balance to zero.
+// Each subset of people whose balances cancel out can settle among
+// themselves in one payment fewer than its size, so the plan splits the
+// group into as many such subsets as possible and settles each greedily.
+func settleUp(nets []int64) []settlePayment {
+	var people []int
+	for i, net := range nets {
+		if net != 0 {
+			people = append(people, i)
+		}
+	}
+	if len(people) == 0 {
+		return nil
+	}
+	if len(people) > maxExactSettleUp {
+		return settleGreedily(nets, people)
+	}
+
+	n := len(people)
+	full := 1<<n - 1
+	sums := make([]int64, full+1)
+	for mask := 1; mask <= full; mask++ {
+		low := mask & -mask
+		index := 0
+		for 1<<index != low {
+			index++
+		}
+		sums[mask] = sums[mask^low] + nets[people[index]]
+	}
+
+	// groups[mask] is the most zero-sum subsets that mask can be split
+	// into, building it up one person at a time
+	groups := make([]int, full+1)
+	for mask := 1; mask <= full; mask++ {
+		best := 0
+		for i := 0; i < n; i++ {
+			if mask&(1<<i) != 0 && groups[mask^(1<<i)] > best {
+				best = groups[mask^(1<<i)]
+			}
+		}
+		if sums[mask] == 0 {
+			best++
+		}
+		groups[mask] = best
+	}
+
+	// Walk back down, closing a subset each time the remainder sums to zero
+	var payments []settlePayment
+	var subset []int
+	mask := full
+	for mask != 0 {
+		for i := 0; i < n; i++ {
+			if mask&(1<<i) == 0 {
+				continue
+			}
+			bonus := 0
+			if sums[mask] == 0 {
+				bonus = 1
+			}
+			if groups[mask^(1<<i)]+bonus == groups[mask] {
+				subset = append(subset, people[i])
+				mask ^= 1 << i
+				break
+			}
+		}
+		if sums[mask] == 0 {
+			payments = append(payments, settleGreedily(nets, subset)...)
+			subset = nil
+		}
+	}
+	return payments
+}
+
+// settleGreedily has the largest debtor pay the largest creditor until
+// everyone in the subset is even
+func settleGreedily(nets []int64, people []int) []settlePayment {
+	balances := map[int]int64{}
+	for _, person := range people {
+		balances[person] = nets[person]
+	}
+
+	var payments []settlePayment
+	for {
+		debtor, creditor := -1, -1
+		for _, person := range people {
+			if balances[person] < 0 && (debtor < 0 || balances[person] < balances[debtor]) {
+				debtor = person
+			}
+			if balances[person] > 0 && (creditor < 0 || balances[person] > balances[creditor]) {
+				creditor = person
+			}
+		}
+		if debtor < 0 || creditor < 0 {
+			return payments
+		}
+
+		cents := min(-balances[debtor], balances[creditor])
+		payments = append(payments, settlePayment{from: debtor, to: creditor, cents: cents})
+		balances[debtor] += cents
+		balances[creditor] -= cents
+	}
+}
@@ -0,0 +1,188 @@
+package usecase
+
+import (
+	"reflect"
+	"testing"
+
+	"personal-finance-tracker/domain/entities"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func testGroup(names ...string) *entities.ExpenseGroup {
+	group := &entities.ExpenseGroup{Currency: "USD"}
+	for _, name := range names {
+		group.Participants = append(group.Participants, entities.Participant{ID: primitive.NewObjectID(), Name: name})
+	}
+	return group
+}
+
+func TestAllocateCents(t *testing.T) {
+	tests := []struct {
+		name    string
+		total   int64
+		weights []float64
+		want    []int64
+	}{
+		{"even split", 900, []float64{1, 1, 1}, []int64{300, 300, 300}},
+		{"leftover cent goes first", 1000, []float64{1, 1, 1}, []int64{334, 333, 333}},
+		{"largest remainder wins", 200, []float64{1, 2}, []int64{67, 133}},
+		{"percentages", 1000, []float64{50, 25, 25}, []int64{500, 250, 250}},
+		{"single cent", 1, []float64{1, 1}, []int64{1, 0}},
+		{"zero weight gets nothing", 500, []float64{0, 3, 2}, []int64{0, 300, 200}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := allocateCents(tt.total, tt.weights)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("allocateCents(%d, %v) = %v, want %v", tt.total, tt.weights, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitExpense(t *testing.T) {
+	group := testGroup("Ana", "Ben", "Cy")
+	ana, ben, cy := group.Participants[0].ID, group.Participants[1].ID, group.Participants[2].ID
+	split := func(id primitive.ObjectID, value float64) entities.ExpenseSplit {
+		return entities.ExpenseSplit{ParticipantID: id, Value: value}
+	}
+
+	tests := []struct {
+		name    string
+		method  string
+		amount  float64
+		splits  []entities.ExpenseSplit
+		want    []float64
+		wantErr bool
+	}{
+		{name: "equal over the whole group by default", amount: 100, want: []float64{33.34, 33.33, 33.33}},
+		{name: "equal over the listed participants", method: entities.SplitEqual, amount: 10, splits: []entities.ExpenseSplit{split(ana, 0), split(cy, 0)}, want: []float64{5, 5}},
+		{name: "exact amounts", method: entities.SplitExact, amount: 30, splits: []entities.ExpenseSplit{split(ana, 10.5), split(ben, 19.5)}, want: []float64{10.5, 19.5}},
+		{name: "exact amounts that do not add up", method: entities.SplitExact, amount: 30, splits: []entities.ExpenseSplit{split(ana, 10), split(ben, 19.99)}, wantErr: true},
+		{name: "percentages", method: entities.SplitPercentage, amount: 80, splits: []entities.ExpenseSplit{split(ana, 50), split(ben, 30), split(cy, 20)}, want: []float64{40, 24, 16}},
+		{name: "percentages not adding up to 100", method: entities.SplitPercentage, amount: 80, splits: []entities.ExpenseSplit{split(ana, 50), split(ben, 30)}, wantErr: true},
+		{name: "shares", method: entities.SplitShares, amount: 100, splits: []entities.ExpenseSplit{split(ana, 2), split(ben, 1)}, want: []float64{66.67, 33.33}},
+		{name: "no shares", method: entities.SplitShares, amount: 100, splits: []entities.ExpenseSplit{split(ana, 0)}, wantErr: true},
+		{name: "non-equal split without splits", method: entities.SplitShares, amount: 100, wantErr: true},
+		{name: "participant outside the group", method: entities.SplitEqual, amount: 10, splits: []entities.ExpenseSplit{split(primitive.NewObjectID(), 0)}, wantErr: true},
+		{name: "participant listed twice", method: entities.SplitShares, amount: 10, splits: []entities.ExpenseSplit{split(ana, 1), split(ana, 1)}, wantErr: true},
+		{name: "negative value", method: entities.SplitShares, amount: 10, splits: []entities.ExpenseSplit{split(ana, 2), split(ben, -1)}, wantErr: true},
+		{name: "unknown method", method: "halves", amount: 10, splits: []entities.ExpenseSplit{split(ana, 1)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expense := &entities.GroupExpense{Amount: tt.amount, SplitMethod: tt.method, Splits: tt.splits}
+			err := splitExpense(expense, group)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			var got []float64
+			for _, split := range expense.Splits {
+				got = append(got, split.Amount)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("amounts = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGroupBalances(t *testing.T) {
+	group := testGroup("Ana", "Ben", "Cy")
+	ana, ben := group.Participants[0].ID, group.Participants[1].ID
+
+	expenses := []entities.GroupExpense{
+		{PaidBy: ana, Amount: 90, Splits: []entities.ExpenseSplit{
+			{ParticipantID: group.Participants[0].ID, Amount: 30},
+			{ParticipantID: group.Participants[1].ID, Amount: 30},
+			{ParticipantID: group.Participants[2].ID, Amount: 30},
+		}},
+		{PaidBy: ben, Amount: 20, Splits: []entities.ExpenseSplit{
+			{ParticipantID: group.Participants[2].ID, Amount: 20},
+		}},
+	}
+
+	result := groupBalances(group, expenses)
+	wantNets := []float64{60, -10, -50}
+	for i, balance := range result.Balances {
+		if balance.Net != wantNets[i] {
+			t.Errorf("%s net = %v, want %v", balance.Name, balance.Net, wantNets[i])
+		}
+	}
+
+	want := []entities.SuggestedPayment{
+		{From: group.Participants[2].ID, FromName: "Cy", To: ana, ToName: "Ana", Amount: 50},
+		{From: ben, FromName: "Ben", To: ana, ToName: "Ana", Amount: 10},
+	}
+	if !reflect.DeepEqual(result.SettleUp, want) {
+		t.Errorf("settle up = %+v, want %+v", result.SettleUp, want)
+	}
+}
+
+func TestSettleUp(t *testing.T) {
+	tests := []struct {
+		name         string
+		nets         []int64
+		wantPayments int
+	}{
+		{"everyone even", []int64{0, 0, 0}, 0},
+		{"one debt", []int64{-500, 500}, 1},
+		{"two debtors, one creditor", []int64{-300, -200, 500}, 2},
+		{"independent pairs settle apart", []int64{400, -400, 1000, -300, -700}, 3},
+		{"three pairs mixed together", []int64{250, -100, -250, 100, 75, -75}, 3},
+		{"no zero-sum subsets", []int64{700, 300, -400, -300, -300}, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payments := settleUp(tt.nets)
+			if len(payments) != tt.wantPayments {
+				t.Errorf("payments = %+v, want %d of them", payments, tt.wantPayments)
+			}
+			checkSettled(t, tt.nets, payments)
+		})
+	}
+}
+
+func TestSettleUpLargeGroup(t *testing.T) {
+	// Beyond maxExactSettleUp the greedy plan is used; it still settles
+	// everyone, in fewer payments than there are people
+	var nets []int64
+	for i := 0; i < maxExactSettleUp+4; i++ {
+		nets = append(nets, int64(i*37%11)-5)
+	}
+	var sum int64
+	for _, net := range nets {
+		sum += net
+	}
+	nets[0] -= sum
+
+	payments := settleUp(nets)
+	if len(payments) >= len(nets) {
+		t.Errorf("%d payments for %d people", len(payments), len(nets))
+	}
+	checkSettled(t, nets, payments)
+}
+
+func checkSettled(t *testing.T, nets []int64, payments []settlePayment) {
+	t.Helper()
+	balances := append([]int64(nil), nets...)
+	for _, payment := range payments {
+		if payment.cents <= 0 {
+			t.Errorf("payment %+v is not positive", payment)
+		}
+		balances[payment.from] += payment.cents
+		balances[payment.to] -= payment.cents
+	}
+	for i, balance := range balances {
+		if balance != 0 {
+			t.Errorf("person %d left at %d", i, balance)
+		}
+	}
+}
@@ -0,0 +1,326 @@
+package usecase
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"time"
+
+	"personal-finance-tracker/domain/entities"
+	repoInterface "personal-finance-tracker/domain/interface"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	defaultGoalPriority = 3
+	maxGoalPriority     = 5
+	daysPerMonth        = 365.25 / 12
+)
+
+type GoalUsecase struct {
+	goalRepo          repoInterface.GoalRepository
+	userRepo          repoInterface.UserRepository
+	attachmentUsecase *AttachmentUsecase
+	// householdUsecase checks access to goals shared with a household
+	householdUsecase *HouseholdUsecase
+}
+
+func NewGoalUsecase(goalRepo repoInterface.GoalRepository, userRepo repoInterface.UserRepository, attachmentUsecase *AttachmentUsecase, householdUsecase *HouseholdUsecase) *GoalUsecase {
+	return &GoalUsecase{
+		goalRepo:          goalRepo,
+		userRepo:          userRepo,
+		attachmentUsecase: attachmentUsecase,
+		householdUsecase:  householdUsecase,
+	}
+}
+
+// authorizeGoal returns the goal if the user owns it, or if it is shared
+// with a household where the user has at least the given role. Anyone else
+// gets "goal not found".
+func (u *GoalUsecase) authorizeGoal(userID, goalID, role string) (*entities.Goal, error) {
+	memberID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, errors.New("invalid user id")
+	}
+	goal, err := u.goalRepo.FindGoalByID(goalID)
+	if err != nil {
+		return nil, err
+	}
+	if goal.UserID == memberID {
+		return goal, nil
+	}
+	if goal.HouseholdID == nil {
+		return nil, errors.New("goal not found")
+	}
+	if _, err := u.householdUsecase.Authorize(userID, goal.HouseholdID.Hex(), role); err != nil {
+		if strings.HasPrefix(err.Error(), "forbidden") {
+			return nil, err
+		}
+		return nil, errors.New("goal not found")
+	}
+	return goal, nil
+}
+
+func (u *GoalUsecase) CreateGoal(userID string, goal *entities.Goal) (*entities.Goal, error) {
+	ownerID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, errors.New("invalid user id")
+	}
+	if err := validateGoal(goal); err != nil {
+		return nil, err
+	}
+	// Sharing a goal takes the right to change the household's data
+	if goal.HouseholdID != nil {
+		if _, err := u.householdUsecase.Authorize(userID, goal.HouseholdID.Hex(), entities.HouseholdRoleEditor); err != nil {
+			return nil, err
+		}
+	}
+
+	// Goals are tracked in the user's base currency unless told otherwise
+	if goal.Currency == "" {
+		user, err := u.userRepo.GetUserByID(userID)
+		if err != nil {
+			return nil, err
+		}
+		goal.Currency = user.Currency
+	}
+
+	goal.ID = primitive.NilObjectID
+	goal.UserID = ownerID
+	goal.Contributions = []entities.Contribution{}
+	goal.CreatedAt = time.Now()
+	goal.UpdatedAt = time.Now()
+
+	createdGoal, err := u.goalRepo.CreateGoal(goal)
+	if err != nil {
+		return nil, errors.New("Failed to create goal: " + err.Error())
+	}
+	return createdGoal, nil
+}
+
+func (u *GoalUsecase) GetGoal(userID, id string) (*entities.Goal, error) {
+	return u.authorizeGoal(userID, id, entities.HouseholdRoleViewer)
+}
+
+// ListGoals lists the user's own goals, or with householdID the goals
+// shared with that household
+func (u *GoalUsecase) ListGoals(userID, householdID string, page entities.PageRequest) (*entities.Page[entities.Goal], error) {
+	var goals *entities.Page[entities.Goal]
+	var err error
+	if householdID != "" {
+		if _, err := u.householdUsecase.Authorize(userID, householdID, entities.HouseholdRoleViewer); err != nil {
+			return nil, err
+		}
+		goals, err = u.goalRepo.ListHouseholdGoals(householdID, page)
+	} else {
+		goals, err = u.goalRepo.ListGoals(userID, page)
+	}
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "invalid") {
+			return nil, err
+		}
+		return nil, errors.New("Database error: " + err.Error())
+	}
+	return goals, nil
+}
+
+// UpdateGoal replaces the editable fields. Only the goal's owner can
+// change which household it is shared with.
+func (u *GoalUsecase) UpdateGoal(userID, id string, input *entities.Goal) (*entities.Goal, error) {
+	goal, err := u.authorizeGoal(userID, id, entities.HouseholdRoleEditor)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateGoal(input); err != nil {
+		return nil, err
+	}
+	if goal.UserID.Hex() == userID {
+		if input.HouseholdID != nil && (goal.HouseholdID == nil || *input.HouseholdID != *goal.HouseholdID) {
+			if _, err := u.householdUsecase.Authorize(userID, input.HouseholdID.Hex(), entities.HouseholdRoleEditor); err != nil {
+				return nil, err
+			}
+		}
+		goal.HouseholdID = input.HouseholdID
+	}
+
+	goal.Name = input.Name
+	goal.TargetAmount = input.TargetAmount
+	goal.TargetDate = input.TargetDate
+	goal.Priority = input.Priority
+	goal.Notes = input.Notes
+	if input.Currency != "" {
+		goal.Currency = input.Currency
+	}
+	goal.UpdatedAt = time.Now()
+
+	return u.goalRepo.UpdateGoal(goal)
+}
+
+func (u *GoalUsecase) DeleteGoal(userID, id string) error {
+	goal, err := u.authorizeGoal(userID, id, entities.HouseholdRoleEditor)
+	if err != nil {
+		return err
+	}
+	ownerID := goal.UserID.Hex()
+	if err := u.goalRepo.DeleteGoal(id, ownerID); err != nil {
+		return err
+	}
+	return u.attachmentUsecase.DeleteParentAttachments(ownerID, entities.AttachmentParentGoal, id)
+}
+
+// AddContribution records money set aside for a goal. Negative amounts are
+// withdrawals, as long as they don't take the goal below zero.
+func (u *GoalUsecase) AddContribution(userID, goalID string, contribution entities.Contribution) (*entities.Goal, error) {
+	if contribution.Amount == 0 {
+		return nil, errors.New("contribution amount must not be zero")
+	}
+	if contribution.Date.IsZero() {
+		contribution.Date = time.Now()
+	}
+	if contribution.Date.After(time.Now()) {
+		return nil, errors.New("contribution date cannot be in the future")
+	}
+
+	goal, err := u.authorizeGoal(userID, goalID, entities.HouseholdRoleEditor)
+	if err != nil {
+		return nil, err
+	}
+	if contribution.Amount < 0 && goal.CurrentAmount()+contribution.Amount < 0 {
+		return nil, errors.New("withdrawal exceeds the amount saved")
+	}
+
+	contribution.ID = primitive.NewObjectID()
+	updated, err := u.goalRepo.AddContribution(goalID, goal.UserID.Hex(), contribution)
+	if err != nil && contribution.Amount < 0 && err.Error() == "goal not found" {
+		// The goal was there a moment ago, so a concurrent withdrawal got in first
+		return nil, errors.New("withdrawal exceeds the amount saved")
+	}
+	return updated, err
+}
+
+// RemoveContribution deletes a contribution, unless removing a deposit
+// would leave later withdrawals taking the goal below zero
+func (u *GoalUsecase) RemoveContribution(userID, goalID, contributionID string) (*entities.Goal, error) {
+	goal, err := u.authorizeGoal(userID, goalID, entities.HouseholdRoleEditor)
+	if err != nil {
+		return nil, err
+	}
+	ownerID := goal.UserID.Hex()
+	objectID, err := primitive.ObjectIDFromHex(contributionID)
+	if err != nil {
+		return nil, errors.New("invalid contribution id")
+	}
+	var contribution *entities.Contribution
+	for i := range goal.Contributions {
+		if goal.Contributions[i].ID == objectID {
+			contribution = &goal.Contributions[i]
+		}
+	}
+	if contribution == nil {
+		return nil, errors.New("contribution not found")
+	}
+	if goal.CurrentAmount()-contribution.Amount < 0 {
+		return nil, errors.New("removing this contribution would leave the goal below zero")
+	}
+
+	updated, err := u.goalRepo.RemoveContribution(goalID, ownerID, *contribution)
+	if err != nil && err.Error() == "goal not found" {
+		// Something changed since the goal was read: the contribution is
+		// gone, or a withdrawal made since depends on it
+		current, err := u.goalRepo.GetGoalByID(goalID, ownerID)
+		if err != nil {
+			return nil, err
+		}
+		for _, remaining := range current.Contributions {
+			if remaining.ID == objectID {
+				return nil, errors.New("removing this contribution would leave the goal below zero")
+			}
+		}
+		return nil, errors.New("contribution not found")
+	}
+	return updated, err
+}
+
+// GetProjection estimates the completion date from the average contribution
+// rate since the first contribution, and the monthly amount needed to hit
+// the target date
+func (u *GoalUsecase) GetProjection(userID, goalID string) (*entities.GoalProjection, error) {
+	goal, err := u.authorizeGoal(userID, goalID, entities.HouseholdRoleViewer)
+	if err != nil {
+		return nil, err
+	}
+	return projectGoal(goal, time.Now()), nil
+}
+
+func projectGoal(goal *entities.Goal, now time.Time) *entities.GoalProjection {
+	current := goal.CurrentAmount()
+	remaining := math.Max(goal.TargetAmount-current, 0)
+
+	projection := &entities.GoalProjection{
+		GoalID:          goal.ID,
+		CurrentAmount:   current,
+		RemainingAmount: remaining,
+		Progress:        math.Min(current/goal.TargetAmount, 1),
+		Completed:       remaining == 0,
+	}
+
+	if len(goal.Contributions) > 0 {
+		first := goal.Contributions[0].Date
+		for _, contribution := range goal.Contributions {
+			if contribution.Date.Before(first) {
+				first = contribution.Date
+			}
+		}
+		// Measure over at least a month so a single deposit doesn't look like a daily habit
+		months := math.Max(now.Sub(first).Hours()/24/daysPerMonth, 1)
+		projection.AverageMonthlyContribution = current / months
+	}
+
+	if projection.Completed {
+		projection.OnTrack = true
+		return projection
+	}
+
+	if projection.AverageMonthlyContribution > 0 {
+		days := remaining / projection.AverageMonthlyContribution * daysPerMonth
+		completion := now.Add(time.Duration(days * 24 * float64(time.Hour)))
+		projection.ProjectedCompletionDate = &completion
+	}
+
+	if goal.TargetDate != nil {
+		monthsLeft := goal.TargetDate.Sub(now).Hours() / 24 / daysPerMonth
+		if monthsLeft > 0 {
+			projection.RequiredMonthlyContribution = remaining / math.Max(monthsLeft, 1)
+		} else {
+			projection.RequiredMonthlyContribution = remaining
+		}
+		projection.OnTrack = projection.ProjectedCompletionDate != nil &&
+			!projection.ProjectedCompletionDate.After(*goal.TargetDate)
+	} else {
+		projection.OnTrack = projection.ProjectedCompletionDate != nil
+	}
+
+	return projection
+}
+
+func validateGoal(goal *entities.Goal) error {
+	goal.Name = strings.TrimSpace(goal.Name)
+	if goal.Name == "" {
+		return errors.New("goal name is required")
+	}
+	if goal.TargetAmount <= 0 {
+		return errors.New("target amount must be positive")
+	}
+	if goal.Priority == 0 {
+		goal.Priority = defaultGoalPriority
+	}
+	if goal.Priority < 1 || goal.Priority > maxGoalPriority {
+		return errors.New("priority must be between 1 and 5")
+	}
+	goal.Currency = strings.ToUpper(goal.Currency)
+	if goal.Currency != "" && !currencyCodeRegex.MatchString(goal.Currency) {
+		return errors.New("invalid currency code")
+	}
+	return nil
+}
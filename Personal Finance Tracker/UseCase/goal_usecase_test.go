@@ -0,0 +1,129 @@
+package usecase
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"personal-finance-tracker/domain/entities"
+)
+
+func TestProjectGoal(t *testing.T) {
+	now := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	months := func(n float64) time.Time {
+		return now.Add(time.Duration(n * daysPerMonth * 24 * float64(time.Hour)))
+	}
+	deposit := func(amount float64, at time.Time) entities.Contribution {
+		return entities.Contribution{Amount: amount, Date: at}
+	}
+	ptr := func(t time.Time) *time.Time { return &t }
+
+	tests := []struct {
+		name           string
+		goal           entities.Goal
+		wantCurrent    float64
+		wantAverage    float64
+		wantRequired   float64
+		wantCompletion *time.Time
+		wantOnTrack    bool
+		wantCompleted  bool
+	}{
+		{
+			name:          "completed",
+			goal:          entities.Goal{TargetAmount: 1000, Contributions: []entities.Contribution{deposit(600, months(-2)), deposit(500, months(-1))}},
+			wantCurrent:   1100,
+			wantAverage:   550,
+			wantOnTrack:   true,
+			wantCompleted: true,
+		},
+		{
+			name:         "no contributions yet",
+			goal:         entities.Goal{TargetAmount: 1200, TargetDate: ptr(months(12))},
+			wantRequired: 100,
+		},
+		{
+			name:           "on track for the target date",
+			goal:           entities.Goal{TargetAmount: 1200, TargetDate: ptr(months(12)), Contributions: []entities.Contribution{deposit(600, months(-6))}},
+			wantCurrent:    600,
+			wantAverage:    100,
+			wantRequired:   50,
+			wantCompletion: ptr(months(6)),
+			wantOnTrack:    true,
+		},
+		{
+			name:           "behind the target date",
+			goal:           entities.Goal{TargetAmount: 1200, TargetDate: ptr(months(3)), Contributions: []entities.Contribution{deposit(600, months(-6))}},
+			wantCurrent:    600,
+			wantAverage:    100,
+			wantRequired:   200,
+			wantCompletion: ptr(months(6)),
+		},
+		{
+			name:           "target date already passed",
+			goal:           entities.Goal{TargetAmount: 1200, TargetDate: ptr(months(-1)), Contributions: []entities.Contribution{deposit(600, months(-6))}},
+			wantCurrent:    600,
+			wantAverage:    100,
+			wantRequired:   600,
+			wantCompletion: ptr(months(6)),
+		},
+		{
+			name:           "less than a month to go counts as one",
+			goal:           entities.Goal{TargetAmount: 1200, TargetDate: ptr(months(0.5)), Contributions: []entities.Contribution{deposit(600, months(-6))}},
+			wantCurrent:    600,
+			wantAverage:    100,
+			wantRequired:   600,
+			wantCompletion: ptr(months(6)),
+		},
+		{
+			name:           "no target date",
+			goal:           entities.Goal{TargetAmount: 1200, Contributions: []entities.Contribution{deposit(400, months(-4)), deposit(-100, months(-1))}},
+			wantCurrent:    300,
+			wantAverage:    75,
+			wantCompletion: ptr(months(12)),
+			wantOnTrack:    true,
+		},
+		{
+			name:           "single recent deposit is averaged over a month",
+			goal:           entities.Goal{TargetAmount: 1200, Contributions: []entities.Contribution{deposit(300, now.AddDate(0, 0, -1))}},
+			wantCurrent:    300,
+			wantAverage:    300,
+			wantCompletion: ptr(months(3)),
+			wantOnTrack:    true,
+		},
+		{
+			name: "no target date and nothing saved",
+			goal: entities.Goal{TargetAmount: 1200},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := projectGoal(&tt.goal, now)
+			if !closeTo(got.CurrentAmount, tt.wantCurrent) || !closeTo(got.RemainingAmount, math.Max(tt.goal.TargetAmount-tt.wantCurrent, 0)) {
+				t.Errorf("current = %v, remaining = %v, want %v saved", got.CurrentAmount, got.RemainingAmount, tt.wantCurrent)
+			}
+			if !closeTo(got.Progress, math.Min(tt.wantCurrent/tt.goal.TargetAmount, 1)) {
+				t.Errorf("progress = %v", got.Progress)
+			}
+			if !closeTo(got.AverageMonthlyContribution, tt.wantAverage) {
+				t.Errorf("average = %v, want %v", got.AverageMonthlyContribution, tt.wantAverage)
+			}
+			if !closeTo(got.RequiredMonthlyContribution, tt.wantRequired) {
+				t.Errorf("required = %v, want %v", got.RequiredMonthlyContribution, tt.wantRequired)
+			}
+			switch {
+			case tt.wantCompletion == nil && got.ProjectedCompletionDate != nil:
+				t.Errorf("completion = %v, want none", got.ProjectedCompletionDate)
+			case tt.wantCompletion != nil && (got.ProjectedCompletionDate == nil || got.ProjectedCompletionDate.Sub(*tt.wantCompletion).Abs() > time.Minute):
+				t.Errorf("completion = %v, want %v", got.ProjectedCompletionDate, tt.wantCompletion)
+			}
+			if got.OnTrack != tt.wantOnTrack || got.Completed != tt.wantCompleted {
+				t.Errorf("on track = %v, completed = %v, want %v, %v", got.OnTrack, got.Completed, tt.wantOnTrack, tt.wantCompleted)
+			}
+		})
+	}
+}
+
+func closeTo(got, want float64) bool {
+	return math.Abs(got-want) < 1e-6
+}
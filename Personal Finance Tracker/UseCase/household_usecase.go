@@ -0,0 +1,367 @@
+package usecase
+
+import (
+	"errors"
+	"log"
+	"strings"
+	"time"
+	"unicode"
+
+	"personal-finance-tracker/Infrastructure/service"
+	"personal-finance-tracker/Infrastructure/utils"
+	"personal-finance-tracker/domain/entities"
+	repoInterface "personal-finance-tracker/domain/interface"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const invitationLifetime = 7 * 24 * time.Hour
+
+// householdRoleRank orders roles so a higher role includes the lower ones
+var householdRoleRank = map[string]int{
+	entities.HouseholdRoleViewer: 1,
+	entities.HouseholdRoleEditor: 2,
+	entities.HouseholdRoleOwner:  3,
+}
+
+type HouseholdUsecase struct {
+	householdRepo  repoInterface.HouseholdRepository
+	invitationRepo repoInterface.HouseholdInvitationRepository
+	userRepo       repoInterface.UserRepository
+	goalRepo       repoInterface.GoalRepository
+	emailService   *services.EmailService
+}
+
+func NewHouseholdUsecase(householdRepo repoInterface.HouseholdRepository, invitationRepo repoInterface.HouseholdInvitationRepository, userRepo repoInterface.UserRepository, goalRepo repoInterface.GoalRepository, emailService *services.EmailService) *HouseholdUsecase {
+	return &HouseholdUsecase{
+		householdRepo:  householdRepo,
+		invitationRepo: invitationRepo,
+		userRepo:       userRepo,
+		goalRepo:       goalRepo,
+		emailService:   emailService,
+	}
+}
+
+// Authorize returns the household if the user is a member with at least
+// the given role. Non-members get "household not found" so they cannot
+// probe which households exist.
+func (u *HouseholdUsecase) Authorize(userID, householdID, role string) (*entities.Household, error) {
+	memberID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, errors.New("invalid user id")
+	}
+	household, err := u.householdRepo.GetHouseholdByID(householdID)
+	if err != nil {
+		return nil, err
+	}
+
+	member := household.Member(memberID)
+	if member == nil {
+		return nil, errors.New("household not found")
+	}
+	if householdRoleRank[member.Role] < householdRoleRank[role] {
+		return nil, errors.New("forbidden: requires " + role + " role")
+	}
+	return household, nil
+}
+
+func (u *HouseholdUsecase) CreateHousehold(userID string, household *entities.Household) (*entities.Household, error) {
+	ownerID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, errors.New("invalid user id")
+	}
+	household.Name = strings.TrimSpace(household.Name)
+	if err := validateHouseholdName(household.Name); err != nil {
+		return nil, err
+	}
+
+	household.ID = primitive.NilObjectID
+	household.CreatedBy = ownerID
+	household.Members = []entities.HouseholdMember{{
+		UserID:   ownerID,
+		Role:     entities.HouseholdRoleOwner,
+		JoinedAt: time.Now(),
+	}}
+	household.CreatedAt = time.Now()
+	household.UpdatedAt = time.Now()
+
+	createdHousehold, err := u.householdRepo.CreateHousehold(household)
+	if err != nil {
+		return nil, errors.New("Failed to create household: " + err.Error())
+	}
+	return createdHousehold, nil
+}
+
+func (u *HouseholdUsecase) GetHousehold(userID, id string) (*entities.Household, error) {
+	return u.Authorize(userID, id, entities.HouseholdRoleViewer)
+}
+
+func (u *HouseholdUsecase) ListHouseholds(userID string, page entities.PageRequest) (*entities.Page[entities.Household], error) {
+	households, err := u.householdRepo.ListHouseholds(userID, page)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "invalid") {
+			return nil, err
+		}
+		return nil, errors.New("Database error: " + err.Error())
+	}
+	return households, nil
+}
+
+func (u *HouseholdUsecase) UpdateHousehold(userID, id string, input *entities.Household) (*entities.Household, error) {
+	household, err := u.Authorize(userID, id, entities.HouseholdRoleOwner)
+	if err != nil {
+		return nil, err
+	}
+	name := strings.TrimSpace(input.Name)
+	if err := validateHouseholdName(name); err != nil {
+		return nil, err
+	}
+
+	household.Name = name
+	household.UpdatedAt = time.Now()
+	return u.householdRepo.UpdateHousehold(household)
+}
+
+func (u *HouseholdUsecase) DeleteHousehold(userID, id string) error {
+	if _, err := u.Authorize(userID, id, entities.HouseholdRoleOwner); err != nil {
+		return err
+	}
+	if err := u.householdRepo.DeleteHousehold(id); err != nil {
+		return err
+	}
+	if err := u.invitationRepo.DeleteInvitationsByHousehold(id); err != nil {
+		log.Printf("⚠️ Failed to delete invitations of household %s: %v", id, err)
+	}
+	if err := u.goalRepo.UnshareGoals(id, ""); err != nil {
+		log.Printf("⚠️ Failed to unshare goals of household %s: %v", id, err)
+	}
+	return nil
+}
+
+// InviteMember emails an invitation link to join the household with the
+// given role. Whoever signs in with that email address can accept it.
+// Inviting the same address again replaces the earlier invitation, so only
+// the newest link works.
+func (u *HouseholdUsecase) InviteMember(userID, householdID, email, role string) (*entities.HouseholdInvitation, error) {
+	household, err := u.Authorize(userID, householdID, entities.HouseholdRoleOwner)
+	if err != nil {
+		return nil, err
+	}
+	email = strings.ToLower(strings.TrimSpace(email))
+	if err := utils.IsEmailValid(email); err != nil {
+		return nil, err
+	}
+	if _, ok := householdRoleRank[role]; !ok {
+		return nil, errors.New("invalid role: must be owner, editor or viewer")
+	}
+
+	// Already a member?
+	if invitee, err := u.userRepo.GetUserByEmail(email); err == nil && household.Member(invitee.ID) != nil {
+		return nil, errors.New("already a member of this household")
+	}
+
+	inviter, err := u.userRepo.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	token, err := newSecretToken()
+	if err != nil {
+		return nil, errors.New("Failed to create invitation: " + err.Error())
+	}
+
+	invitation := &entities.HouseholdInvitation{
+		HouseholdID: household.ID,
+		Email:       email,
+		Role:        role,
+		TokenHash:   hashSecretToken(token),
+		InvitedBy:   inviter.ID,
+		ExpiresAt:   time.Now().Add(invitationLifetime),
+		CreatedAt:   time.Now(),
+	}
+	if err := u.invitationRepo.DeleteInvitationsByEmail(householdID, email); err != nil {
+		return nil, errors.New("Database error: " + err.Error())
+	}
+	createdInvitation, err := u.invitationRepo.CreateInvitation(invitation)
+	if err != nil {
+		return nil, errors.New("Failed to create invitation: " + err.Error())
+	}
+
+	inviterName := inviter.Name
+	if inviterName == "" {
+		inviterName = inviter.Email
+	}
+	if err := u.emailService.SendHouseholdInvitation(email, inviterName, household.Name, role, token); err != nil {
+		return nil, errors.New("Failed to send invitation: " + err.Error())
+	}
+	return createdInvitation, nil
+}
+
+func (u *HouseholdUsecase) ListInvitations(userID, householdID string) ([]entities.HouseholdInvitation, error) {
+	if _, err := u.Authorize(userID, householdID, entities.HouseholdRoleOwner); err != nil {
+		return nil, err
+	}
+	invitations, err := u.invitationRepo.ListInvitations(householdID)
+	if err != nil {
+		return nil, errors.New("Database error: " + err.Error())
+	}
+	return invitations, nil
+}
+
+func (u *HouseholdUsecase) RevokeInvitation(userID, householdID, invitationID string) error {
+	if _, err := u.Authorize(userID, householdID, entities.HouseholdRoleOwner); err != nil {
+		return err
+	}
+	return u.invitationRepo.DeleteInvitation(invitationID, householdID)
+}
+
+// PreviewInvitation shows what an emailed invitation link is for, so the
+// invitee knows which account to sign in with before accepting it
+func (u *HouseholdUsecase) PreviewInvitation(token string) (*entities.HouseholdInvitationPreview, error) {
+	invitation, err := u.pendingInvitation(token)
+	if err != nil {
+		return nil, err
+	}
+	household, err := u.householdRepo.GetHouseholdByID(invitation.HouseholdID.Hex())
+	if err != nil {
+		return nil, err
+	}
+	return &entities.HouseholdInvitationPreview{
+		HouseholdName: household.Name,
+		Email:         invitation.Email,
+		Role:          invitation.Role,
+		ExpiresAt:     invitation.ExpiresAt,
+	}, nil
+}
+
+// AcceptInvitation adds the signed-in user to the household, provided the
+// invitation was sent to their email address and has not expired
+func (u *HouseholdUsecase) AcceptInvitation(userID, token string) (*entities.Household, error) {
+	invitation, err := u.pendingInvitation(token)
+	if err != nil {
+		return nil, err
+	}
+	householdID := invitation.HouseholdID.Hex()
+
+	user, err := u.userRepo.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(user.Email, invitation.Email) {
+		return nil, errors.New("forbidden: invitation was sent to a different email")
+	}
+
+	household, err := u.householdRepo.AddMember(householdID, entities.HouseholdMember{
+		UserID:   user.ID,
+		Role:     invitation.Role,
+		JoinedAt: time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := u.invitationRepo.DeleteInvitation(invitation.ID.Hex(), householdID); err != nil {
+		log.Printf("⚠️ Failed to delete accepted invitation %s: %v", invitation.ID.Hex(), err)
+	}
+	return household, nil
+}
+
+// pendingInvitation looks up the invitation for an emailed token, deleting
+// it if it has expired
+func (u *HouseholdUsecase) pendingInvitation(token string) (*entities.HouseholdInvitation, error) {
+	if token == "" {
+		return nil, errors.New("token is required")
+	}
+	invitation, err := u.invitationRepo.GetInvitationByTokenHash(hashSecretToken(token))
+	if err != nil {
+		if err.Error() == "invitation not found" {
+			return nil, err
+		}
+		return nil, errors.New("Database error: " + err.Error())
+	}
+
+	if time.Now().After(invitation.ExpiresAt) {
+		if err := u.invitationRepo.DeleteInvitation(invitation.ID.Hex(), invitation.HouseholdID.Hex()); err != nil {
+			log.Printf("⚠️ Failed to delete expired invitation %s: %v", invitation.ID.Hex(), err)
+		}
+		return nil, errors.New("invitation has expired")
+	}
+	return invitation, nil
+}
+
+func (u *HouseholdUsecase) UpdateMemberRole(userID, householdID, memberID, role string) (*entities.Household, error) {
+	household, err := u.Authorize(userID, householdID, entities.HouseholdRoleOwner)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := householdRoleRank[role]; !ok {
+		return nil, errors.New("invalid role: must be owner, editor or viewer")
+	}
+	if err := checkOwnerRemains(household, memberID, role); err != nil {
+		return nil, err
+	}
+
+	return u.householdRepo.UpdateMemberRole(householdID, memberID, role)
+}
+
+// RemoveMember lets owners remove anyone and other members leave. The last
+// member leaving deletes the household.
+func (u *HouseholdUsecase) RemoveMember(userID, householdID, memberID string) (*entities.Household, error) {
+	role := entities.HouseholdRoleOwner
+	if memberID == userID {
+		role = entities.HouseholdRoleViewer
+	}
+	household, err := u.Authorize(userID, householdID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(household.Members) == 1 && memberID == userID {
+		return nil, u.DeleteHousehold(userID, householdID)
+	}
+	if err := checkOwnerRemains(household, memberID, ""); err != nil {
+		return nil, err
+	}
+
+	updated, err := u.householdRepo.RemoveMember(householdID, memberID)
+	if err != nil {
+		return nil, err
+	}
+	// Goals the member shared leave the household with them
+	if err := u.goalRepo.UnshareGoals(householdID, memberID); err != nil {
+		log.Printf("⚠️ Failed to unshare goals of %s from household %s: %v", memberID, householdID, err)
+	}
+	return updated, nil
+}
+
+// validateHouseholdName rejects names that are empty or contain control
+// characters, since the name goes into invitation email subjects
+func validateHouseholdName(name string) error {
+	if name == "" {
+		return errors.New("name is required")
+	}
+	if strings.IndexFunc(name, unicode.IsControl) >= 0 {
+		return errors.New("name cannot contain control characters")
+	}
+	return nil
+}
+
+// checkOwnerRemains rejects giving the member a new role (empty when they
+// are removed) if that would leave the household without an owner
+func checkOwnerRemains(household *entities.Household, memberID, newRole string) error {
+	if newRole == entities.HouseholdRoleOwner {
+		return nil
+	}
+	owners := 0
+	changesOwner := false
+	for _, member := range household.Members {
+		if member.Role == entities.HouseholdRoleOwner {
+			owners++
+			if member.UserID.Hex() == memberID {
+				changesOwner = true
+			}
+		}
+	}
+	if changesOwner && owners == 1 {
+		return errors.New("a household needs at least one owner: make another member owner first")
+	}
+	return nil
+}
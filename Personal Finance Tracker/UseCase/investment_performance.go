@@ -0,0 +1,359 @@
+package usecase
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"personal-finance-tracker/domain/entities"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const daysPerYear = 365.0
+
+// GetPerformance returns time- and money-weighted returns between from and
+// to (whole days, inclusive). With an accountID only that account is
+// reported, otherwise the portfolio and every account. Results are cached
+// per period until the user's events or the price history change.
+//
+// Accounts hold securities only, so buys count as money put in and sales
+// and dividends as money taken out.
+func (u *InvestmentUsecase) GetPerformance(userID, accountID string, from, to time.Time) (*entities.PerformanceReport, error) {
+	if !from.IsZero() && to.Before(from) {
+		return nil, errors.New("invalid period: to is before from")
+	}
+
+	report := &entities.PerformanceReport{Accounts: []entities.PerformanceResult{}}
+
+	if accountID != "" {
+		account, err := u.accountRepo.GetAccountByID(accountID, userID)
+		if err != nil {
+			return nil, err
+		}
+		result, err := u.accountPerformance(account, from, to)
+		if err != nil {
+			return nil, err
+		}
+		report.Accounts = append(report.Accounts, *result)
+		return report, nil
+	}
+
+	events, err := u.eventRepo.GetAllUserEvents(userID)
+	if err != nil {
+		if err.Error() == "invalid user id" {
+			return nil, err
+		}
+		return nil, errors.New("Database error: " + err.Error())
+	}
+
+	byAccount := map[primitive.ObjectID][]entities.InvestmentEvent{}
+	var order []primitive.ObjectID
+	for _, event := range events {
+		if _, ok := byAccount[event.AccountID]; !ok {
+			order = append(order, event.AccountID)
+		}
+		byAccount[event.AccountID] = append(byAccount[event.AccountID], event)
+	}
+
+	portfolio, err := u.cachedPerformance(userID, entities.PerformanceScopePortfolio, nil, events, from, to)
+	if err != nil {
+		return nil, err
+	}
+	report.Portfolio = portfolio
+
+	for _, id := range order {
+		accountID := id
+		result, err := u.cachedPerformance(userID, entities.PerformanceScopeAccount, &accountID, byAccount[id], from, to)
+		if err != nil {
+			return nil, err
+		}
+		report.Accounts = append(report.Accounts, *result)
+	}
+
+	return report, nil
+}
+
+func (u *InvestmentUsecase) accountPerformance(account *entities.InvestmentAccount, from, to time.Time) (*entities.PerformanceResult, error) {
+	events, err := u.getEvents(account.UserID.Hex(), account.ID.Hex())
+	if err != nil {
+		return nil, err
+	}
+	return u.cachedPerformance(account.UserID.Hex(), entities.PerformanceScopeAccount, &account.ID, events, from, to)
+}
+
+func (u *InvestmentUsecase) cachedPerformance(userID, scope string, accountID *primitive.ObjectID, events []entities.InvestmentEvent, from, to time.Time) (*entities.PerformanceResult, error) {
+	// Default to the whole history of the scope
+	if from.IsZero() {
+		if len(events) == 0 {
+			from = to
+		} else {
+			from = startOfDay(events[0].Date)
+		}
+	}
+
+	accountKey := ""
+	if accountID != nil {
+		accountKey = accountID.Hex()
+	}
+	cached, err := u.perfCacheRepo.GetResult(userID, scope, accountKey, from, to)
+	if err == nil {
+		return cached, nil
+	}
+	if err.Error() != "performance result not found" {
+		return nil, errors.New("Database error: " + err.Error())
+	}
+
+	result, err := u.computePerformance(events, from, to)
+	if err != nil {
+		return nil, err
+	}
+	ownerID, _ := primitive.ObjectIDFromHex(userID)
+	result.UserID = ownerID
+	result.Scope = scope
+	result.AccountID = accountID
+	result.ComputedAt = time.Now()
+
+	if err := u.perfCacheRepo.SaveResult(result); err != nil {
+		return nil, errors.New("Database error: " + err.Error())
+	}
+	return result, nil
+}
+
+// externalFlow is money moving into (positive) or out of (negative) the
+// securities on one day
+type externalFlow struct {
+	date   time.Time
+	amount float64
+}
+
+func (u *InvestmentUsecase) computePerformance(events []entities.InvestmentEvent, from, to time.Time) (*entities.PerformanceResult, error) {
+	valuer := &portfolioValuer{usecase: u, events: events, prices: map[string]float64{}}
+	result := &entities.PerformanceResult{From: from, To: to}
+
+	// Net flows per day inside the period
+	var flows []externalFlow
+	for _, event := range events {
+		day := startOfDay(event.Date)
+		if day.Before(from) || day.After(to) {
+			continue
+		}
+		amount := 0.0
+		switch event.Type {
+		case entities.InvestmentEventBuy:
+			amount = event.Quantity*event.Price + event.Fees
+		case entities.InvestmentEventSell:
+			amount = -(event.Quantity*event.Price - event.Fees)
+		case entities.InvestmentEventDividend:
+			amount = -event.Amount
+		default:
+			continue
+		}
+		if len(flows) > 0 && flows[len(flows)-1].date.Equal(day) {
+			flows[len(flows)-1].amount += amount
+		} else {
+			flows = append(flows, externalFlow{date: day, amount: amount})
+		}
+		result.NetContributions += amount
+	}
+
+	// Value at the close of the day before the period starts
+	startValue, err := valuer.valueAt(endOfDay(from.AddDate(0, 0, -1)))
+	if err != nil {
+		return nil, err
+	}
+	endValue, err := valuer.valueAt(endOfDay(to))
+	if err != nil {
+		return nil, err
+	}
+	result.StartValue = roundCents(startValue)
+	result.EndValue = roundCents(endValue)
+	result.NetContributions = roundCents(result.NetContributions)
+
+	// TWR chains the returns between flows. Each sub-period ends at the
+	// close of a flow day, with that day's flow taken out of its end value.
+	growth := 1.0
+	measured := false
+	previous := startValue
+	for _, flow := range flows {
+		value, err := valuer.valueAt(endOfDay(flow.date))
+		if err != nil {
+			return nil, err
+		}
+		if previous > 0 {
+			growth *= (value - flow.amount) / previous
+			measured = true
+		}
+		previous = value
+	}
+	if len(flows) == 0 || flows[len(flows)-1].date.Before(startOfDay(to)) {
+		if previous > 0 {
+			growth *= endValue / previous
+			measured = true
+		}
+	}
+	if measured {
+		twr := growth - 1
+		result.TWR = &twr
+		days := to.Sub(from).Hours()/24 + 1
+		if days >= daysPerYear {
+			annualized := math.Pow(growth, daysPerYear/days) - 1
+			result.AnnualizedTWR = &annualized
+		}
+	}
+
+	// MWR is the rate that discounts the investor's cash flows to zero:
+	// money put in is negative, the final value and withdrawals positive
+	var cashFlows []externalFlow
+	if startValue > 0 {
+		cashFlows = append(cashFlows, externalFlow{date: from, amount: -startValue})
+	}
+	for _, flow := range flows {
+		cashFlows = append(cashFlows, externalFlow{date: flow.date, amount: -flow.amount})
+	}
+	if endValue > 0 {
+		cashFlows = append(cashFlows, externalFlow{date: to, amount: endValue})
+	}
+	if mwr, ok := xirr(cashFlows); ok {
+		result.MWR = &mwr
+	}
+
+	return result, nil
+}
+
+// portfolioValuer values the positions built from events at a point in
+// time, memoizing price lookups
+type portfolioValuer struct {
+	usecase *InvestmentUsecase
+	events  []entities.InvestmentEvent
+	prices  map[string]float64
+}
+
+// valueAt sums quantity times the latest close for every position held at
+// the given time. Securities without a price history are valued at their
+// last traded price.
+func (v *portfolioValuer) valueAt(at time.Time) (float64, error) {
+	quantities := map[string]float64{}
+	lastTrade := map[string]float64{}
+	for _, event := range v.events {
+		if event.Date.After(at) {
+			break
+		}
+		switch event.Type {
+		case entities.InvestmentEventBuy:
+			quantities[event.Symbol] += event.Quantity
+			lastTrade[event.Symbol] = event.Price
+		case entities.InvestmentEventSell:
+			quantities[event.Symbol] -= event.Quantity
+			lastTrade[event.Symbol] = event.Price
+		case entities.InvestmentEventSplit:
+			quantities[event.Symbol] *= event.SplitRatio
+			lastTrade[event.Symbol] /= event.SplitRatio
+		}
+	}
+
+	total := 0.0
+	for symbol, quantity := range quantities {
+		if quantity <= shareEpsilon {
+			continue
+		}
+		price, err := v.price(symbol, at)
+		if err != nil {
+			return 0, err
+		}
+		if price == 0 {
+			price = lastTrade[symbol]
+		}
+		total += quantity * price
+	}
+	return total, nil
+}
+
+func (v *portfolioValuer) price(symbol string, at time.Time) (float64, error) {
+	key := symbol + "|" + at.Format(time.RFC3339)
+	if price, ok := v.prices[key]; ok {
+		return price, nil
+	}
+
+	price := 0.0
+	record, err := v.usecase.priceRepo.GetPriceOnOrBefore(symbol, at)
+	if err != nil {
+		if err.Error() != "price not found" {
+			return 0, errors.New("Database error: " + err.Error())
+		}
+	} else {
+		price = record.Close
+	}
+	v.prices[key] = price
+	return price, nil
+}
+
+// xirr solves for the annual rate at which the flows' net present value is
+// zero, using Newton's method and falling back to bisection
+func xirr(flows []externalFlow) (float64, bool) {
+	hasIn, hasOut := false, false
+	for _, flow := range flows {
+		hasIn = hasIn || flow.amount < 0
+		hasOut = hasOut || flow.amount > 0
+	}
+	if !hasIn || !hasOut {
+		return 0, false
+	}
+
+	start := flows[0].date
+	npv := func(rate float64) (float64, float64) {
+		value, derivative := 0.0, 0.0
+		for _, flow := range flows {
+			years := flow.date.Sub(start).Hours() / 24 / daysPerYear
+			discount := math.Pow(1+rate, years)
+			value += flow.amount / discount
+			derivative -= years * flow.amount / (discount * (1 + rate))
+		}
+		return value, derivative
+	}
+
+	rate := 0.1
+	for i := 0; i < 100; i++ {
+		value, derivative := npv(rate)
+		if math.Abs(value) < 1e-7 {
+			return rate, true
+		}
+		if derivative == 0 {
+			break
+		}
+		next := rate - value/derivative
+		if next <= -1 || math.IsNaN(next) || math.IsInf(next, 0) {
+			break
+		}
+		rate = next
+	}
+
+	low, high := -0.9999, 100.0
+	lowValue, _ := npv(low)
+	highValue, _ := npv(high)
+	if lowValue*highValue > 0 {
+		return 0, false
+	}
+	for i := 0; i < 200; i++ {
+		mid := (low + high) / 2
+		midValue, _ := npv(mid)
+		if math.Abs(midValue) < 1e-7 {
+			return mid, true
+		}
+		if lowValue*midValue < 0 {
+			high = mid
+		} else {
+			low, lowValue = mid, midValue
+		}
+	}
+	return (low + high) / 2, true
+}
+
+func startOfDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func endOfDay(t time.Time) time.Time {
+	return startOfDay(t).Add(24*time.Hour - time.Nanosecond)
+}
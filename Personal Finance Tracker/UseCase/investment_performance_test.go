@@ -0,0 +1,197 @@
+package usecase
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"personal-finance-tracker/domain/entities"
+)
+
+// stubPriceRepository serves closing prices from memory
+type stubPriceRepository struct {
+	closes map[string][]entities.SecurityPrice
+}
+
+func (r *stubPriceRepository) UpsertPrices(prices []entities.SecurityPrice) (int64, error) {
+	return 0, errors.New("not implemented")
+}
+
+func (r *stubPriceRepository) GetPriceOnOrBefore(symbol string, date time.Time) (*entities.SecurityPrice, error) {
+	var found *entities.SecurityPrice
+	for i, price := range r.closes[symbol] {
+		if !price.Date.After(date) {
+			found = &r.closes[symbol][i]
+		}
+	}
+	if found == nil {
+		return nil, errors.New("price not found")
+	}
+	return found, nil
+}
+
+func (r *stubPriceRepository) ListPrices(symbol string, from, to time.Time, page entities.PageRequest) (*entities.Page[entities.SecurityPrice], error) {
+	return nil, errors.New("not implemented")
+}
+
+func TestXIRR(t *testing.T) {
+	start := costBasisDate(2023, 1, 1)
+	tests := []struct {
+		name   string
+		flows  []externalFlow
+		want   float64
+		wantOK bool
+	}{
+		{
+			name:   "ten percent over a year",
+			flows:  []externalFlow{{start, -1000}, {start.AddDate(0, 0, 365), 1100}},
+			want:   0.1,
+			wantOK: true,
+		},
+		{
+			name:   "half lost over a year",
+			flows:  []externalFlow{{start, -1000}, {start.AddDate(0, 0, 365), 500}},
+			want:   -0.5,
+			wantOK: true,
+		},
+		{
+			name:   "break even",
+			flows:  []externalFlow{{start, -1000}, {start.AddDate(0, 6, 0), 1000}},
+			want:   0,
+			wantOK: true,
+		},
+		{
+			name:  "money only going in",
+			flows: []externalFlow{{start, -1000}, {start.AddDate(0, 6, 0), -500}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := xirr(tt.flows)
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && math.Abs(got-tt.want) > 1e-6 {
+				t.Errorf("rate = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestXIRRSolvesIrregularFlows(t *testing.T) {
+	start := costBasisDate(2023, 1, 1)
+	flows := []externalFlow{
+		{start, -1000},
+		{start.AddDate(0, 3, 10), -2500},
+		{start.AddDate(0, 7, 0), 400},
+		{start.AddDate(1, 2, 0), 3600},
+	}
+
+	rate, ok := xirr(flows)
+	if !ok {
+		t.Fatal("expected a rate")
+	}
+	npv := 0.0
+	for _, flow := range flows {
+		years := flow.date.Sub(start).Hours() / 24 / daysPerYear
+		npv += flow.amount / math.Pow(1+rate, years)
+	}
+	if math.Abs(npv) > 1e-5 {
+		t.Errorf("npv at %v = %v, want 0", rate, npv)
+	}
+}
+
+func TestComputePerformance(t *testing.T) {
+	jan := func(day int) time.Time { return costBasisDate(2024, 1, day) }
+	closes := func(values ...float64) []entities.SecurityPrice {
+		var prices []entities.SecurityPrice
+		days := []int{1, 15, 31}
+		for i, value := range values {
+			prices = append(prices, entities.SecurityPrice{Symbol: "ACME", Date: jan(days[i]), Close: value})
+		}
+		return prices
+	}
+
+	tests := []struct {
+		name              string
+		events            []entities.InvestmentEvent
+		prices            []entities.SecurityPrice
+		wantTWR           float64
+		wantMWRSign       int
+		wantEnd, wantFlow float64
+	}{
+		{
+			name:        "single buy that gains ten percent",
+			events:      []entities.InvestmentEvent{buyEvent(jan(1), 10, 100, 0)},
+			prices:      closes(100, 105, 110),
+			wantTWR:     0.1,
+			wantMWRSign: 1,
+			wantEnd:     1100,
+			wantFlow:    1000,
+		},
+		{
+			name:        "buying more at the top is not the manager's fault",
+			events:      []entities.InvestmentEvent{buyEvent(jan(1), 10, 100, 0), buyEvent(jan(15), 10, 200, 0)},
+			prices:      closes(100, 200, 100),
+			wantTWR:     0,
+			wantMWRSign: -1,
+			wantEnd:     2000,
+			wantFlow:    3000,
+		},
+		{
+			name:        "sale proceeds leave the portfolio",
+			events:      []entities.InvestmentEvent{buyEvent(jan(1), 10, 100, 0), sellEvent(jan(15), 5, 120, 0)},
+			prices:      closes(100, 120, 120),
+			wantTWR:     0.2,
+			wantMWRSign: 1,
+			wantEnd:     600,
+			wantFlow:    400,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &InvestmentUsecase{priceRepo: &stubPriceRepository{closes: map[string][]entities.SecurityPrice{"ACME": tt.prices}}}
+			result, err := u.computePerformance(tt.events, jan(1), jan(31))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.TWR == nil || math.Abs(*result.TWR-tt.wantTWR) > 1e-9 {
+				t.Errorf("twr = %v, want %v", result.TWR, tt.wantTWR)
+			}
+			if result.MWR == nil {
+				t.Fatal("expected a money-weighted return")
+			}
+			if sign := math.Copysign(1, *result.MWR); int(sign) != tt.wantMWRSign {
+				t.Errorf("mwr = %v, want sign %d", *result.MWR, tt.wantMWRSign)
+			}
+			if result.StartValue != 0 || result.EndValue != tt.wantEnd {
+				t.Errorf("values = %v..%v, want 0..%v", result.StartValue, result.EndValue, tt.wantEnd)
+			}
+			if result.NetContributions != tt.wantFlow {
+				t.Errorf("net contributions = %v, want %v", result.NetContributions, tt.wantFlow)
+			}
+			if result.AnnualizedTWR != nil {
+				t.Errorf("a one-month period should not be annualized")
+			}
+		})
+	}
+}
+
+func TestComputePerformanceFallsBackToTradePrice(t *testing.T) {
+	u := &InvestmentUsecase{priceRepo: &stubPriceRepository{}}
+	events := []entities.InvestmentEvent{buyEvent(costBasisDate(2024, 1, 1), 10, 100, 0)}
+
+	result, err := u.computePerformance(events, costBasisDate(2024, 1, 1), costBasisDate(2024, 1, 31))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.EndValue != 1000 {
+		t.Errorf("end value = %v, want the last trade value 1000", result.EndValue)
+	}
+	if result.TWR == nil || *result.TWR != 0 {
+		t.Errorf("twr = %v, want 0", result.TWR)
+	}
+}
@@ -0,0 +1,432 @@
+package usecase
+
+import (
+	"errors"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"personal-finance-tracker/Infrastructure/service"
+	"personal-finance-tracker/domain/entities"
+	repoInterface "personal-finance-tracker/domain/interface"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var symbolRegex = regexp.MustCompile(`^[A-Z0-9.\-^=]{1,20}$`)
+
+var costBasisMethods = map[string]bool{
+	entities.CostBasisFIFO:        true,
+	entities.CostBasisLIFO:        true,
+	entities.CostBasisAverage:     true,
+	entities.CostBasisSpecificLot: true,
+}
+
+type InvestmentUsecase struct {
+	accountRepo   repoInterface.InvestmentAccountRepository
+	eventRepo     repoInterface.InvestmentEventRepository
+	priceRepo     repoInterface.PriceRepository
+	perfCacheRepo repoInterface.PerformanceCacheRepository
+	// attachmentUsecase removes a deleted account's attachments
+	attachmentUsecase *AttachmentUsecase
+}
+
+func NewInvestmentUsecase(accountRepo repoInterface.InvestmentAccountRepository, eventRepo repoInterface.InvestmentEventRepository, priceRepo repoInterface.PriceRepository, perfCacheRepo repoInterface.PerformanceCacheRepository, attachmentUsecase *AttachmentUsecase) *InvestmentUsecase {
+	return &InvestmentUsecase{
+		accountRepo:       accountRepo,
+		eventRepo:         eventRepo,
+		priceRepo:         priceRepo,
+		perfCacheRepo:     perfCacheRepo,
+		attachmentUsecase: attachmentUsecase,
+	}
+}
+
+func (u *InvestmentUsecase) CreateAccount(userID string, account *entities.InvestmentAccount) (*entities.InvestmentAccount, error) {
+	ownerID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, errors.New("invalid user id")
+	}
+	if err := validateInvestmentAccount(account); err != nil {
+		return nil, err
+	}
+
+	account.ID = primitive.NilObjectID
+	account.UserID = ownerID
+	account.CreatedAt = time.Now()
+	account.UpdatedAt = time.Now()
+
+	createdAccount, err := u.accountRepo.CreateAccount(account)
+	if err != nil {
+		return nil, errors.New("Failed to create investment account: " + err.Error())
+	}
+	return createdAccount, nil
+}
+
+func (u *InvestmentUsecase) GetAccount(userID, id string) (*entities.InvestmentAccount, error) {
+	return u.accountRepo.GetAccountByID(id, userID)
+}
+
+func (u *InvestmentUsecase) ListAccounts(userID string, page entities.PageRequest) (*entities.Page[entities.InvestmentAccount], error) {
+	accounts, err := u.accountRepo.ListAccounts(userID, page)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "invalid") {
+			return nil, err
+		}
+		return nil, errors.New("Database error: " + err.Error())
+	}
+	return accounts, nil
+}
+
+// UpdateAccount changes the account details. Switching the cost basis
+// method is only allowed if the whole history still replays under it.
+func (u *InvestmentUsecase) UpdateAccount(userID, id string, input *entities.InvestmentAccount) (*entities.InvestmentAccount, error) {
+	account, err := u.accountRepo.GetAccountByID(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateInvestmentAccount(input); err != nil {
+		return nil, err
+	}
+
+	if input.CostBasisMethod != account.CostBasisMethod {
+		events, err := u.getEvents(userID, id)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := replayEvents(events, input.CostBasisMethod, time.Time{}); err != nil {
+			return nil, err
+		}
+	}
+
+	account.Name = input.Name
+	account.Broker = input.Broker
+	account.Currency = input.Currency
+	account.CostBasisMethod = input.CostBasisMethod
+	account.UpdatedAt = time.Now()
+
+	return u.accountRepo.UpdateAccount(account)
+}
+
+func (u *InvestmentUsecase) DeleteAccount(userID, id string) error {
+	if err := u.accountRepo.DeleteAccount(id, userID); err != nil {
+		return err
+	}
+	if err := u.eventRepo.DeleteEventsByAccount(id, userID); err != nil {
+		return errors.New("Database error: " + err.Error())
+	}
+	if err := u.attachmentUsecase.DeleteParentAttachments(userID, entities.AttachmentParentInvestmentAccount, id); err != nil {
+		return err
+	}
+	return u.invalidatePerformance(userID)
+}
+
+// AddEvent records a buy, sell, dividend or split after checking that the
+// account history, including back-dated events, stays consistent
+func (u *InvestmentUsecase) AddEvent(userID, accountID string, event *entities.InvestmentEvent) (*entities.InvestmentEvent, error) {
+	account, err := u.accountRepo.GetAccountByID(accountID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateInvestmentEvent(event, account.CostBasisMethod); err != nil {
+		return nil, err
+	}
+
+	events, err := u.getEvents(userID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	event.ID = primitive.NewObjectID()
+	event.UserID = account.UserID
+	event.AccountID = account.ID
+	event.CreatedAt = time.Now()
+
+	events = append(events, *event)
+	sortEvents(events)
+	if _, err := replayEvents(events, account.CostBasisMethod, time.Time{}); err != nil {
+		return nil, err
+	}
+
+	createdEvent, err := u.eventRepo.CreateEvent(event)
+	if err != nil {
+		return nil, errors.New("Failed to create investment event: " + err.Error())
+	}
+	if err := u.invalidatePerformance(userID); err != nil {
+		return nil, err
+	}
+	return createdEvent, nil
+}
+
+func (u *InvestmentUsecase) ListEvents(userID, accountID string, page entities.PageRequest) (*entities.Page[entities.InvestmentEvent], error) {
+	if _, err := u.accountRepo.GetAccountByID(accountID, userID); err != nil {
+		return nil, err
+	}
+
+	events, err := u.eventRepo.ListEvents(accountID, userID, page)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "invalid") {
+			return nil, err
+		}
+		return nil, errors.New("Database error: " + err.Error())
+	}
+	return events, nil
+}
+
+// DeleteEvent removes an event unless later sales depend on it
+func (u *InvestmentUsecase) DeleteEvent(userID, accountID, eventID string) error {
+	account, err := u.accountRepo.GetAccountByID(accountID, userID)
+	if err != nil {
+		return err
+	}
+	events, err := u.getEvents(userID, accountID)
+	if err != nil {
+		return err
+	}
+
+	remaining := make([]entities.InvestmentEvent, 0, len(events))
+	for _, event := range events {
+		if event.ID.Hex() != eventID {
+			remaining = append(remaining, event)
+		}
+	}
+	if len(remaining) == len(events) {
+		return errors.New("investment event not found")
+	}
+	if _, err := replayEvents(remaining, account.CostBasisMethod, time.Time{}); err != nil {
+		return errors.New("event cannot be deleted: " + err.Error())
+	}
+
+	if err := u.eventRepo.DeleteEvent(eventID, accountID, userID); err != nil {
+		return err
+	}
+	return u.invalidatePerformance(userID)
+}
+
+// GetHoldings returns open positions and their lots as of a date, valued
+// with the latest imported price on or before that date
+func (u *InvestmentUsecase) GetHoldings(userID, accountID string, asOf time.Time) (*entities.HoldingsReport, error) {
+	account, err := u.accountRepo.GetAccountByID(accountID, userID)
+	if err != nil {
+		return nil, err
+	}
+	events, err := u.getEvents(userID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	book, err := replayEvents(events, account.CostBasisMethod, asOf)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &entities.HoldingsReport{
+		AccountID: account.ID,
+		AsOf:      asOf,
+		Holdings:  []entities.Holding{},
+	}
+
+	symbols := make([]string, 0, len(book.lots))
+	for symbol := range book.lots {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+
+	for _, symbol := range symbols {
+		holding := entities.Holding{Symbol: symbol, Lots: []entities.TaxLot{}}
+		for _, lot := range book.lots[symbol] {
+			holding.Quantity += lot.Quantity
+			holding.CostBasis += lot.CostBasis
+			holding.Lots = append(holding.Lots, *lot)
+		}
+		holding.AverageCost = holding.CostBasis / holding.Quantity
+		holding.CostBasis = roundCents(holding.CostBasis)
+		report.TotalCostBasis += holding.CostBasis
+
+		price, err := u.priceRepo.GetPriceOnOrBefore(symbol, asOf)
+		if err != nil {
+			if err.Error() != "price not found" {
+				return nil, errors.New("Database error: " + err.Error())
+			}
+			report.MissingPrices = append(report.MissingPrices, symbol)
+		} else {
+			holding.Price = price.Close
+			holding.PriceDate = &price.Date
+			holding.MarketValue = roundCents(holding.Quantity * price.Close)
+			holding.UnrealizedGain = roundCents(holding.MarketValue - holding.CostBasis)
+			report.TotalMarketValue += holding.MarketValue
+			report.TotalUnrealizedGain += holding.UnrealizedGain
+		}
+
+		report.Holdings = append(report.Holdings, holding)
+	}
+
+	report.TotalCostBasis = roundCents(report.TotalCostBasis)
+	report.TotalMarketValue = roundCents(report.TotalMarketValue)
+	report.TotalUnrealizedGain = roundCents(report.TotalUnrealizedGain)
+	return report, nil
+}
+
+// GetRealizedGains lists gains from sales and the dividends received
+// between from and to, inclusive
+func (u *InvestmentUsecase) GetRealizedGains(userID, accountID string, from, to time.Time) (*entities.RealizedGainsReport, error) {
+	account, err := u.accountRepo.GetAccountByID(accountID, userID)
+	if err != nil {
+		return nil, err
+	}
+	events, err := u.getEvents(userID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	book, err := replayEvents(events, account.CostBasisMethod, to)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &entities.RealizedGainsReport{
+		AccountID: account.ID,
+		From:      from,
+		To:        to,
+		Gains:     []entities.RealizedGain{},
+	}
+	for _, gain := range book.realized {
+		if gain.Date.Before(from) {
+			continue
+		}
+		report.Gains = append(report.Gains, gain)
+		report.TotalGain += gain.Gain
+		if gain.LongTerm {
+			report.LongTermGain += gain.Gain
+		} else {
+			report.ShortTermGain += gain.Gain
+		}
+	}
+	for _, dividend := range book.dividends {
+		if !dividend.Date.Before(from) {
+			report.Dividends += dividend.Amount
+		}
+	}
+
+	report.TotalGain = roundCents(report.TotalGain)
+	report.LongTermGain = roundCents(report.LongTermGain)
+	report.ShortTermGain = roundCents(report.ShortTermGain)
+	report.Dividends = roundCents(report.Dividends)
+	return report, nil
+}
+
+// ImportPrices stores a closing price history from csv
+func (u *InvestmentUsecase) ImportPrices(symbol string, r io.Reader) (int64, error) {
+	prices, err := services.ParsePriceCSV(r, symbol)
+	if err != nil {
+		return 0, err
+	}
+	for _, price := range prices {
+		if !symbolRegex.MatchString(price.Symbol) {
+			return 0, errors.New("invalid symbol: " + price.Symbol)
+		}
+	}
+
+	count, err := u.priceRepo.UpsertPrices(prices)
+	if err != nil {
+		return 0, errors.New("Database error: " + err.Error())
+	}
+	// New prices can change any cached valuation
+	if err := u.perfCacheRepo.InvalidateAll(); err != nil {
+		return 0, errors.New("Database error: " + err.Error())
+	}
+	return count, nil
+}
+
+func (u *InvestmentUsecase) ListPrices(symbol string, from, to time.Time, page entities.PageRequest) (*entities.Page[entities.SecurityPrice], error) {
+	symbol = strings.ToUpper(strings.TrimSpace(symbol))
+	if !symbolRegex.MatchString(symbol) {
+		return nil, errors.New("invalid symbol")
+	}
+
+	prices, err := u.priceRepo.ListPrices(symbol, from, to, page)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "invalid") {
+			return nil, err
+		}
+		return nil, errors.New("Database error: " + err.Error())
+	}
+	return prices, nil
+}
+
+func (u *InvestmentUsecase) getEvents(userID, accountID string) ([]entities.InvestmentEvent, error) {
+	events, err := u.eventRepo.GetAllEvents(accountID, userID)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "invalid") {
+			return nil, err
+		}
+		return nil, errors.New("Database error: " + err.Error())
+	}
+	return events, nil
+}
+
+// invalidatePerformance drops cached returns once the user's history changes
+func (u *InvestmentUsecase) invalidatePerformance(userID string) error {
+	if err := u.perfCacheRepo.InvalidateUser(userID); err != nil {
+		return errors.New("Database error: " + err.Error())
+	}
+	return nil
+}
+
+func validateInvestmentAccount(account *entities.InvestmentAccount) error {
+	account.Name = strings.TrimSpace(account.Name)
+	if account.Name == "" {
+		return errors.New("account name is required")
+	}
+	account.Currency = strings.ToUpper(account.Currency)
+	if account.Currency != "" && !currencyCodeRegex.MatchString(account.Currency) {
+		return errors.New("invalid currency code")
+	}
+	if account.CostBasisMethod == "" {
+		account.CostBasisMethod = entities.CostBasisFIFO
+	}
+	if !costBasisMethods[account.CostBasisMethod] {
+		return errors.New("invalid cost basis method: must be fifo, lifo, average or specific")
+	}
+	return nil
+}
+
+func validateInvestmentEvent(event *entities.InvestmentEvent, method string) error {
+	event.Symbol = strings.ToUpper(strings.TrimSpace(event.Symbol))
+	if !symbolRegex.MatchString(event.Symbol) {
+		return errors.New("invalid symbol")
+	}
+	if event.Date.IsZero() {
+		event.Date = time.Now()
+	}
+	if event.Fees < 0 {
+		return errors.New("fees cannot be negative")
+	}
+
+	switch event.Type {
+	case entities.InvestmentEventBuy, entities.InvestmentEventSell:
+		if event.Quantity <= 0 {
+			return errors.New("quantity must be positive")
+		}
+		if event.Price < 0 {
+			return errors.New("price cannot be negative")
+		}
+		if event.Type == entities.InvestmentEventBuy || method != entities.CostBasisSpecificLot {
+			event.Lots = nil
+		}
+		event.Amount = 0
+		event.SplitRatio = 0
+	case entities.InvestmentEventDividend:
+		if event.Amount <= 0 {
+			return errors.New("dividend amount must be positive")
+		}
+		event.Quantity, event.Price, event.SplitRatio, event.Lots = 0, 0, 0, nil
+	case entities.InvestmentEventSplit:
+		if event.SplitRatio <= 0 || event.SplitRatio == 1 {
+			return errors.New("split ratio must be positive and not 1")
+		}
+		event.Quantity, event.Price, event.Amount, event.Fees, event.Lots = 0, 0, 0, 0, nil
+	default:
+		return errors.New("invalid event type: must be buy, sell, dividend or split")
+	}
+	return nil
+}
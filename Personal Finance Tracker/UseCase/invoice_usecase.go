@@ -0,0 +1,573 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"personal-finance-tracker/Infrastructure/service"
+	"personal-finance-tracker/Infrastructure/utils"
+	"personal-finance-tracker/domain/entities"
+	repoInterface "personal-finance-tracker/domain/interface"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	invoiceNumberPrefix    = "INV-"
+	defaultPaymentTermDays = 30
+)
+
+// invoiceReferenceRegex finds invoice numbers in payment references,
+// tolerating the separators and dropped zeros payers tend to use
+var invoiceReferenceRegex = regexp.MustCompile(`(?i)\bINV[\s#:\-]*0*(\d+)\b`)
+
+var invoiceStatuses = map[string]bool{
+	entities.InvoiceStatusDraft:         true,
+	entities.InvoiceStatusSent:          true,
+	entities.InvoiceStatusPartiallyPaid: true,
+	entities.InvoiceStatusPaid:          true,
+	entities.InvoiceStatusVoid:          true,
+}
+
+type InvoiceUsecase struct {
+	invoiceRepo  repoInterface.InvoiceRepository
+	userRepo     repoInterface.UserRepository
+	emailService *services.EmailService
+}
+
+func NewInvoiceUsecase(invoiceRepo repoInterface.InvoiceRepository, userRepo repoInterface.UserRepository, emailService *services.EmailService) *InvoiceUsecase {
+	return &InvoiceUsecase{
+		invoiceRepo:  invoiceRepo,
+		userRepo:     userRepo,
+		emailService: emailService,
+	}
+}
+
+// CreateInvoice saves a draft. Currency defaults to the user's, the issue
+// date to today and the due date to 30 days after it.
+func (u *InvoiceUsecase) CreateInvoice(userID string, invoice *entities.Invoice) (*entities.Invoice, error) {
+	user, err := u.userRepo.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if invoice.Currency == "" {
+		invoice.Currency = user.Currency
+	}
+	if err := validateInvoice(invoice); err != nil {
+		return nil, err
+	}
+
+	invoice.ID = primitive.NilObjectID
+	invoice.UserID = user.ID
+	invoice.Number = ""
+	invoice.Status = entities.InvoiceStatusDraft
+	invoice.AmountPaid = 0
+	invoice.AmountDue = invoice.Total
+	invoice.Payments = []entities.InvoicePayment{}
+	invoice.SentAt = nil
+	invoice.PaidAt = nil
+	invoice.CreatedAt = time.Now()
+	invoice.UpdatedAt = time.Now()
+
+	createdInvoice, err := u.invoiceRepo.CreateInvoice(invoice)
+	if err != nil {
+		return nil, errors.New("Failed to create invoice: " + err.Error())
+	}
+	return createdInvoice, nil
+}
+
+func (u *InvoiceUsecase) GetInvoice(userID, id string) (*entities.Invoice, error) {
+	invoice, err := u.invoiceRepo.GetInvoiceByID(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	setOverdue(invoice, time.Now())
+	return invoice, nil
+}
+
+func (u *InvoiceUsecase) ListInvoices(userID, status string, page entities.PageRequest) (*entities.Page[entities.Invoice], error) {
+	if status != "" && !invoiceStatuses[status] {
+		return nil, errors.New("invalid status: must be draft, sent, partially_paid, paid or void")
+	}
+
+	invoices, err := u.invoiceRepo.ListInvoices(userID, status, page)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "invalid") {
+			return nil, err
+		}
+		return nil, errors.New("Database error: " + err.Error())
+	}
+	now := time.Now()
+	for i := range invoices.Items {
+		setOverdue(&invoices.Items[i], now)
+	}
+	return invoices, nil
+}
+
+// UpdateInvoice edits a draft; issued invoices are final
+func (u *InvoiceUsecase) UpdateInvoice(userID, id string, input *entities.Invoice) (*entities.Invoice, error) {
+	invoice, err := u.invoiceRepo.GetInvoiceByID(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if invoice.Status != entities.InvoiceStatusDraft {
+		return nil, errors.New("only draft invoices can be edited")
+	}
+	if input.Currency == "" {
+		input.Currency = invoice.Currency
+	}
+	if err := validateInvoice(input); err != nil {
+		return nil, err
+	}
+
+	invoice.Client = input.Client
+	invoice.Currency = input.Currency
+	invoice.IssueDate = input.IssueDate
+	invoice.DueDate = input.DueDate
+	invoice.Items = input.Items
+	invoice.TaxLines = input.TaxLines
+	invoice.Subtotal = input.Subtotal
+	invoice.TaxTotal = input.TaxTotal
+	invoice.Total = input.Total
+	invoice.AmountDue = input.Total
+	invoice.Notes = input.Notes
+	invoice.UpdatedAt = time.Now()
+
+	return u.invoiceRepo.UpdateInvoice(invoice)
+}
+
+// DeleteInvoice removes a draft. Issued invoices keep their number and can
+// only be voided, so the numbering has no gaps.
+func (u *InvoiceUsecase) DeleteInvoice(userID, id string) error {
+	invoice, err := u.invoiceRepo.GetInvoiceByID(id, userID)
+	if err != nil {
+		return err
+	}
+	if invoice.Status != entities.InvoiceStatusDraft {
+		return errors.New("issued invoices cannot be deleted: void them instead")
+	}
+	return u.invoiceRepo.DeleteInvoice(id, userID)
+}
+
+// IssueInvoice gives a draft the next invoice number and marks it sent
+// without emailing it, for invoices delivered some other way
+func (u *InvoiceUsecase) IssueInvoice(userID, id string) (*entities.Invoice, error) {
+	invoice, err := u.invoiceRepo.GetInvoiceByID(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if invoice.Status != entities.InvoiceStatusDraft {
+		return nil, errors.New("invoice has already been issued")
+	}
+	return u.issue(invoice)
+}
+
+// SendInvoice emails the invoice PDF to the client, issuing it first if it
+// is still a draft. Open invoices can be sent again as a reminder. A draft
+// stays issued when the email fails, with no sent_at, so it can be sent
+// again under the same number.
+func (u *InvoiceUsecase) SendInvoice(userID, id string) (*entities.Invoice, error) {
+	invoice, err := u.invoiceRepo.GetInvoiceByID(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if invoice.Status == entities.InvoiceStatusPaid || invoice.Status == entities.InvoiceStatusVoid {
+		return nil, errors.New("invoice is " + invoice.Status)
+	}
+	if invoice.Client.Email == "" {
+		return nil, errors.New("client email is required to send an invoice")
+	}
+	user, err := u.userRepo.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if invoice.Status == entities.InvoiceStatusDraft {
+		if invoice, err = u.issue(invoice); err != nil {
+			return nil, err
+		}
+	}
+
+	pdf := services.RenderInvoicePDF(invoice, senderName(user), user.Email)
+	if err := u.emailService.SendInvoice(invoice.Client.Email, invoice.Client.Name, senderName(user), invoice.Number, invoice.AmountDue, invoice.Currency, invoice.DueDate, pdf); err != nil {
+		return nil, fmt.Errorf("Failed to send invoice %s, it is issued but not delivered: %v", invoice.Number, err)
+	}
+
+	now := time.Now()
+	invoice, err = u.invoiceRepo.SetInvoiceSentAt(id, userID, now)
+	if err != nil {
+		return nil, err
+	}
+	setOverdue(invoice, now)
+	return invoice, nil
+}
+
+// VoidInvoice cancels an issued invoice that has no payments. The check is
+// part of the update, so a payment recorded meanwhile stops the void.
+func (u *InvoiceUsecase) VoidInvoice(userID, id string) (*entities.Invoice, error) {
+	invoice, err := u.invoiceRepo.VoidInvoice(id, userID)
+	if err == nil || err.Error() != "invoice not found" {
+		return invoice, err
+	}
+
+	// Read the invoice again to tell why it could not be voided
+	invoice, err = u.invoiceRepo.GetInvoiceByID(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case invoice.Status == entities.InvoiceStatusDraft:
+		return nil, errors.New("draft invoices can be deleted instead")
+	case invoice.Status == entities.InvoiceStatusVoid:
+		return nil, errors.New("invoice is already void")
+	case len(invoice.Payments) > 0:
+		return nil, errors.New("invoice has payments: remove them before voiding")
+	}
+	return nil, errors.New("invoice changed while voiding it: try again")
+}
+
+// RenderPDF returns the invoice as a PDF along with the invoice itself, for
+// naming the download
+func (u *InvoiceUsecase) RenderPDF(userID, id string) ([]byte, *entities.Invoice, error) {
+	invoice, err := u.invoiceRepo.GetInvoiceByID(id, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	user, err := u.userRepo.GetUserByID(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return services.RenderInvoicePDF(invoice, senderName(user), user.Email), invoice, nil
+}
+
+func (u *InvoiceUsecase) RecordPayment(userID, id string, payment entities.InvoicePayment) (*entities.Invoice, error) {
+	invoice, err := u.invoiceRepo.GetInvoiceByID(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	payment.MatchedBy = ""
+	return u.addPayment(invoice, payment)
+}
+
+func (u *InvoiceUsecase) RemovePayment(userID, id, paymentID string) (*entities.Invoice, error) {
+	invoice, err := u.invoiceRepo.GetInvoiceByID(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	objectID, err := primitive.ObjectIDFromHex(paymentID)
+	if err != nil {
+		return nil, errors.New("invalid payment id")
+	}
+
+	var payment *entities.InvoicePayment
+	for i := range invoice.Payments {
+		if invoice.Payments[i].ID == objectID {
+			payment = &invoice.Payments[i]
+		}
+	}
+	if payment == nil {
+		return nil, errors.New("payment not found")
+	}
+
+	invoice, err = u.invoiceRepo.RemovePayment(id, userID, *payment)
+	if err != nil {
+		return nil, err
+	}
+	setOverdue(invoice, time.Now())
+	return invoice, nil
+}
+
+// MatchPayment records an incoming payment against the open invoice it
+// belongs to. An invoice number in the reference wins; otherwise the
+// payment must equal the amount due of exactly one open invoice. Payments
+// that match nothing, or more than one invoice, are not recorded and the
+// possible invoices are returned for the user to pick from.
+func (u *InvoiceUsecase) MatchPayment(userID string, incoming entities.IncomingPayment) (*entities.InvoiceMatch, error) {
+	if incoming.Amount <= 0 {
+		return nil, errors.New("amount must be positive")
+	}
+	incoming.Currency = strings.ToUpper(incoming.Currency)
+
+	open, err := u.invoiceRepo.GetOpenInvoices(userID)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "invalid") {
+			return nil, err
+		}
+		return nil, errors.New("Database error: " + err.Error())
+	}
+	var candidates []entities.Invoice
+	for _, invoice := range open {
+		if incoming.Currency == "" || invoice.Currency == incoming.Currency {
+			candidates = append(candidates, invoice)
+		}
+	}
+
+	match := &entities.InvoiceMatch{Candidates: []entities.Invoice{}}
+	payment := entities.InvoicePayment{Amount: incoming.Amount, Date: incoming.Date, Reference: incoming.Reference}
+	amount := toCents(incoming.Amount)
+
+	var chosen *entities.Invoice
+	if referenced := referencedInvoice(candidates, incoming.Reference); referenced != nil {
+		if amount > toCents(referenced.AmountDue) {
+			match.Candidates = append(match.Candidates, *referenced)
+			return match, nil
+		}
+		chosen = referenced
+		payment.MatchedBy = entities.InvoiceMatchByNumber
+	} else {
+		var sameAmount []entities.Invoice
+		for _, invoice := range candidates {
+			if toCents(invoice.AmountDue) == amount {
+				sameAmount = append(sameAmount, invoice)
+			}
+		}
+		if len(sameAmount) != 1 {
+			if sameAmount != nil {
+				match.Candidates = sameAmount
+			}
+			return match, nil
+		}
+		chosen = &sameAmount[0]
+		payment.MatchedBy = entities.InvoiceMatchByAmount
+	}
+
+	invoice, err := u.addPayment(chosen, payment)
+	if err != nil {
+		return nil, err
+	}
+	match.Matched = true
+	match.MatchedBy = payment.MatchedBy
+	match.Invoice = invoice
+	return match, nil
+}
+
+// GetReceivables totals what clients owe on open invoices, per currency and
+// by how long they are overdue
+func (u *InvoiceUsecase) GetReceivables(userID string) (*entities.Receivables, error) {
+	open, err := u.invoiceRepo.GetOpenInvoices(userID)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "invalid") {
+			return nil, err
+		}
+		return nil, errors.New("Database error: " + err.Error())
+	}
+
+	now := time.Now()
+	today := startOfDay(now)
+	totals := map[string]*entities.ReceivablesTotal{}
+	for i := range open {
+		invoice := &open[i]
+		setOverdue(invoice, now)
+
+		total, ok := totals[invoice.Currency]
+		if !ok {
+			total = &entities.ReceivablesTotal{Currency: invoice.Currency}
+			totals[invoice.Currency] = total
+		}
+		total.Outstanding += invoice.AmountDue
+		switch daysLate := int(today.Sub(startOfDay(invoice.DueDate)).Hours() / 24); {
+		case daysLate <= 0:
+			total.NotYetDue += invoice.AmountDue
+		case daysLate <= 30:
+			total.Overdue1To30 += invoice.AmountDue
+		case daysLate <= 60:
+			total.Overdue31To60 += invoice.AmountDue
+		case daysLate <= 90:
+			total.Overdue61To90 += invoice.AmountDue
+		default:
+			total.OverdueOver90 += invoice.AmountDue
+		}
+	}
+
+	report := &entities.Receivables{Totals: []entities.ReceivablesTotal{}, Invoices: open}
+	for _, total := range totals {
+		total.Outstanding = roundCents(total.Outstanding)
+		total.NotYetDue = roundCents(total.NotYetDue)
+		total.Overdue1To30 = roundCents(total.Overdue1To30)
+		total.Overdue31To60 = roundCents(total.Overdue31To60)
+		total.Overdue61To90 = roundCents(total.Overdue61To90)
+		total.OverdueOver90 = roundCents(total.OverdueOver90)
+		report.Totals = append(report.Totals, *total)
+	}
+	sort.Slice(report.Totals, func(i, j int) bool {
+		return report.Totals[i].Currency < report.Totals[j].Currency
+	})
+	return report, nil
+}
+
+// issue numbers a draft. When a concurrent call issued it first, the number
+// taken here is handed back so the numbering keeps no gap.
+func (u *InvoiceUsecase) issue(invoice *entities.Invoice) (*entities.Invoice, error) {
+	userID := invoice.UserID.Hex()
+	sequence, err := u.invoiceRepo.NextInvoiceSequence(userID)
+	if err != nil {
+		return nil, errors.New("Database error: " + err.Error())
+	}
+
+	invoice.Number = fmt.Sprintf("%s%04d", invoiceNumberPrefix, sequence)
+	invoice.Status = entities.InvoiceStatusSent
+	invoice.UpdatedAt = time.Now()
+	issued, err := u.invoiceRepo.IssueInvoice(invoice)
+	if err != nil {
+		if releaseErr := u.invoiceRepo.ReleaseInvoiceSequence(userID, sequence); releaseErr != nil {
+			log.Printf("⚠️ Failed to release invoice number %d: %v", sequence, releaseErr)
+		}
+		if err.Error() == "invoice not found" {
+			return nil, errors.New("invoice has already been issued")
+		}
+		return nil, err
+	}
+	return issued, nil
+}
+
+func (u *InvoiceUsecase) addPayment(invoice *entities.Invoice, payment entities.InvoicePayment) (*entities.Invoice, error) {
+	if invoice.Status != entities.InvoiceStatusSent && invoice.Status != entities.InvoiceStatusPartiallyPaid {
+		return nil, errors.New("payments can only be recorded on open invoices")
+	}
+	if payment.Amount <= 0 {
+		return nil, errors.New("payment amount must be positive")
+	}
+	payment.Amount = roundCents(payment.Amount)
+	if toCents(payment.Amount) > toCents(invoice.AmountDue) {
+		return nil, errors.New("payment exceeds the amount due")
+	}
+	if payment.Date.IsZero() {
+		payment.Date = time.Now()
+	}
+	payment.ID = primitive.NewObjectID()
+
+	updated, err := u.invoiceRepo.AddPayment(invoice.ID.Hex(), invoice.UserID.Hex(), payment)
+	if err != nil {
+		if err.Error() == "invoice not found" {
+			// A concurrent payment or void got there first
+			return nil, errors.New("payment exceeds the amount due or the invoice is no longer open")
+		}
+		return nil, err
+	}
+	setOverdue(updated, time.Now())
+	return updated, nil
+}
+
+// referencedInvoice returns the invoice whose number appears in the
+// payment reference, if any
+func referencedInvoice(invoices []entities.Invoice, reference string) *entities.Invoice {
+	for _, groups := range invoiceReferenceRegex.FindAllStringSubmatch(reference, -1) {
+		sequence, err := strconv.ParseInt(groups[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		number := fmt.Sprintf("%s%04d", invoiceNumberPrefix, sequence)
+		for i := range invoices {
+			if invoices[i].Number == number {
+				return &invoices[i]
+			}
+		}
+	}
+	return nil
+}
+
+// setOverdue flags open invoices whose due date has passed
+func setOverdue(invoice *entities.Invoice, now time.Time) {
+	open := invoice.Status == entities.InvoiceStatusSent || invoice.Status == entities.InvoiceStatusPartiallyPaid
+	invoice.Overdue = open && startOfDay(invoice.DueDate).Before(startOfDay(now))
+}
+
+func senderName(user *entities.User) string {
+	if user.Name != "" {
+		return user.Name
+	}
+	return user.Email
+}
+
+// validateInvoice checks the client and line items and computes the line,
+// tax and invoice totals
+func validateInvoice(invoice *entities.Invoice) error {
+	invoice.Client.Name = strings.TrimSpace(invoice.Client.Name)
+	if invoice.Client.Name == "" {
+		return errors.New("client name is required")
+	}
+	invoice.Client.Email = strings.ToLower(strings.TrimSpace(invoice.Client.Email))
+	if invoice.Client.Email != "" {
+		if err := utils.IsEmailValid(invoice.Client.Email); err != nil {
+			return err
+		}
+	}
+	invoice.Currency = strings.ToUpper(invoice.Currency)
+	if invoice.Currency == "" {
+		return errors.New("currency is required")
+	}
+	if !currencyCodeRegex.MatchString(invoice.Currency) {
+		return errors.New("invalid currency code")
+	}
+	if invoice.IssueDate.IsZero() {
+		invoice.IssueDate = startOfDay(time.Now())
+	}
+	if invoice.DueDate.IsZero() {
+		invoice.DueDate = startOfDay(invoice.IssueDate).AddDate(0, 0, defaultPaymentTermDays)
+	}
+	if startOfDay(invoice.DueDate).Before(startOfDay(invoice.IssueDate)) {
+		return errors.New("due date cannot be before the issue date")
+	}
+	if len(invoice.Items) == 0 {
+		return errors.New("at least one line item is required")
+	}
+
+	var subtotal, taxTotal int64
+	taxByRate := map[float64]*entities.InvoiceTaxLine{}
+	var rates []float64
+	for i := range invoice.Items {
+		item := &invoice.Items[i]
+		item.Description = strings.TrimSpace(item.Description)
+		if item.Description == "" {
+			return errors.New("line item description is required")
+		}
+		if item.Quantity <= 0 {
+			return errors.New("line item quantity must be positive")
+		}
+		if item.UnitPrice < 0 {
+			return errors.New("line item unit price cannot be negative")
+		}
+		if item.TaxRate < 0 || item.TaxRate > 100 {
+			return errors.New("line item tax rate must be between 0 and 100")
+		}
+
+		// Tax is rounded per line, so lines always add up to the totals
+		net := toCents(item.Quantity * item.UnitPrice)
+		tax := toCents(fromCents(net) * item.TaxRate / 100)
+		item.Net = fromCents(net)
+		item.Tax = fromCents(tax)
+		subtotal += net
+		taxTotal += tax
+
+		line, ok := taxByRate[item.TaxRate]
+		if !ok {
+			line = &entities.InvoiceTaxLine{Rate: item.TaxRate}
+			taxByRate[item.TaxRate] = line
+			rates = append(rates, item.TaxRate)
+		}
+		line.Net = fromCents(toCents(line.Net) + net)
+		line.Tax = fromCents(toCents(line.Tax) + tax)
+	}
+
+	if subtotal+taxTotal <= 0 {
+		return errors.New("invoice total must be positive")
+	}
+
+	sort.Float64s(rates)
+	invoice.TaxLines = []entities.InvoiceTaxLine{}
+	for _, rate := range rates {
+		if rate > 0 {
+			invoice.TaxLines = append(invoice.TaxLines, *taxByRate[rate])
+		}
+	}
+	invoice.Subtotal = fromCents(subtotal)
+	invoice.TaxTotal = fromCents(taxTotal)
+	invoice.Total = fromCents(subtotal + taxTotal)
+	return nil
+}
@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// Card processors and wallets prefix the merchant, as in "SQ *BLUE BOTTLE"
+	processorPrefixRegex = regexp.MustCompile(`^(SQ|TST|SP|PP|PAYPAL|PY|IN|CKE|GOOGLE|APL|BT|DD|WPY|FS)\s*\*\s*`)
+	// After the merchant, a star starts a reference, as in "AMAZON.COM*2K4L31"
+	referenceRegex       = regexp.MustCompile(`^([^*]+)\*.*$`)
+	storeNumberRegex     = regexp.MustCompile(`^#?[0-9][0-9-]*$|#`)
+	maskedCardRegex      = regexp.MustCompile(`(X{2,}|\*{2,})\d*`)
+	descriptionDateRegex = regexp.MustCompile(`\b\d{1,2}/\d{1,2}(/\d{2,4})?\b`)
+	dotComRegex          = regexp.MustCompile(`\.COM\b`)
+	nonWordRegex         = regexp.MustCompile(`[^A-Z0-9&'#-]+`)
+	// Support addresses follow the merchant, as in "UBER TRIP HELP.UBER.COM"
+	helpAddressRegex = regexp.MustCompile(`\b(HELP|SUPPORT|INFO)\.\S*`)
+)
+
+// Words banks put before the merchant on card transactions
+var descriptionNoiseWords = map[string]bool{
+	"POS": true, "DEBIT": true, "CARD": true, "PURCHASE": true,
+	"CHECKCARD": true, "VISA": true, "RECURRING": true,
+}
+
+var usStateCodes = map[string]bool{
+	"AL": true, "AK": true, "AZ": true, "AR": true, "CA": true, "CO": true, "CT": true, "DE": true,
+	"DC": true, "FL": true, "GA": true, "HI": true, "ID": true, "IL": true, "IN": true, "IA": true,
+	"KS": true, "KY": true, "LA": true, "ME": true, "MD": true, "MA": true, "MI": true, "MN": true,
+	"MS": true, "MO": true, "MT": true, "NE": true, "NV": true, "NH": true, "NJ": true, "NM": true,
+	"NY": true, "NC": true, "ND": true, "OH": true, "OK": true, "OR": true, "PA": true, "RI": true,
+	"SC": true, "SD": true, "TN": true, "TX": true, "UT": true, "VT": true, "VA": true, "WA": true,
+	"WV": true, "WI": true, "WY": true,
+}
+
+// Words that start or end multi-word city names, as in "NEW YORK",
+// "SAN FRANCISCO" and "SALT LAKE CITY"
+var (
+	cityPrefixWords = map[string]bool{
+		"NEW": true, "SAN": true, "SANTA": true, "LOS": true, "LAS": true, "EL": true,
+		"ST": true, "SAINT": true, "FORT": true, "FT": true, "MOUNT": true, "MT": true,
+		"PALO": true, "LONG": true, "SALT": true, "NORTH": true, "SOUTH": true,
+		"EAST": true, "WEST": true,
+	}
+	citySuffixWords = map[string]bool{
+		"CITY": true, "BEACH": true, "SPRINGS": true, "FALLS": true, "HEIGHTS": true, "LAKE": true,
+	}
+	// Single-word cities common enough to drop even when the merchant is one word
+	knownCityWords = map[string]bool{
+		"ATLANTA": true, "AUSTIN": true, "BALTIMORE": true, "BOSTON": true, "CHARLOTTE": true,
+		"CHICAGO": true, "CLEVELAND": true, "COLUMBUS": true, "DALLAS": true, "DENVER": true,
+		"DETROIT": true, "HOUSTON": true, "INDIANAPOLIS": true, "MEMPHIS": true, "MIAMI": true,
+		"MILWAUKEE": true, "MINNEAPOLIS": true, "NASHVILLE": true, "OAKLAND": true, "ORLANDO": true,
+		"PHILADELPHIA": true, "PHOENIX": true, "PITTSBURGH": true, "PORTLAND": true, "SACRAMENTO": true,
+		"SEATTLE": true, "TAMPA": true,
+	}
+)
+
+// normalizePayee reduces a raw bank description to a matching key:
+// "SQ *BLUE BOTTLE 8832 SF CA" becomes "BLUE BOTTLE". Processor prefixes,
+// references, masked card numbers and dates are removed, and everything from the first
+// store number on is treated as location and dropped.
+func normalizePayee(description string) string {
+	value := strings.ToUpper(strings.TrimSpace(description))
+	value = processorPrefixRegex.ReplaceAllString(value, "")
+	value = maskedCardRegex.ReplaceAllString(value, " ")
+	value = referenceRegex.ReplaceAllString(value, "$1")
+	value = descriptionDateRegex.ReplaceAllString(value, " ")
+	value = helpAddressRegex.ReplaceAllString(value, " ")
+	value = dotComRegex.ReplaceAllString(value, " ")
+	value = nonWordRegex.ReplaceAllString(value, " ")
+
+	tokens := strings.Fields(value)
+	for len(tokens) > 1 && descriptionNoiseWords[tokens[0]] {
+		tokens = tokens[1:]
+	}
+
+	var kept []string
+	for _, token := range tokens {
+		if storeNumberRegex.MatchString(token) {
+			if len(kept) > 0 {
+				break
+			}
+			continue
+		}
+		kept = append(kept, token)
+	}
+
+	// A trailing state code marks a location that had no store number before it
+	if len(kept) > 2 && usStateCodes[kept[len(kept)-1]] {
+		kept = dropTrailingCity(kept[:len(kept)-1])
+	}
+
+	return strings.Join(kept, " ")
+}
+
+// dropTrailingCity removes the city name that ends tokens, taking in the
+// words of multi-word names. An unrecognised one-word city is only dropped
+// when two tokens remain, so "HOME DEPOT" doesn't lose "DEPOT" as a city.
+func dropTrailingCity(tokens []string) []string {
+	end := len(tokens) - 1
+	recognised := knownCityWords[tokens[end]]
+	for end > 1 && citySuffixWords[tokens[end]] {
+		end--
+		recognised = true
+	}
+	for end > 1 && cityPrefixWords[tokens[end-1]] {
+		end--
+		recognised = true
+	}
+	if !recognised && end < 2 {
+		return tokens
+	}
+	return tokens[:end]
+}
+
+// payeeKeyMatches reports whether key is the payee key or starts with it as
+// whole words, so "BLUE BOTTLE COFFEE" matches the alias "BLUE BOTTLE"
+func payeeKeyMatches(key, payeeKey string) bool {
+	if payeeKey == "" {
+		return false
+	}
+	return key == payeeKey || strings.HasPrefix(key, payeeKey+" ")
+}
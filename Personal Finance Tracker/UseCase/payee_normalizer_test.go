@@ -0,0 +1,59 @@
+package usecase
+
+import "testing"
+
+func TestNormalizePayee(t *testing.T) {
+	tests := []struct {
+		description string
+		want        string
+	}{
+		{"SQ *BLUE BOTTLE 8832 SF CA", "BLUE BOTTLE"},
+		{"TST* JOE'S PIZZA NEW YORK NY", "JOE'S PIZZA"},
+		{"PANERA BREAD LOS ANGELES CA", "PANERA BREAD"},
+		{"IN-N-OUT BURGER SALT LAKE CITY UT", "IN-N-OUT BURGER"},
+		{"CHIPOTLE KANSAS CITY MO", "CHIPOTLE"},
+		{"STARBUCKS SEATTLE WA", "STARBUCKS"},
+		{"HOME DEPOT CA", "HOME DEPOT"},
+		{"SHELL OIL TX", "SHELL OIL"},
+		{"JOE'S DINER SPRINGFIELD IL", "JOE'S DINER"},
+		{"BODEGA SPRINGFIELD IL", "BODEGA SPRINGFIELD"},
+		{"TARGET T-1234 AUSTIN TX", "TARGET T-1234"},
+		{"TARGET #1234 AUSTIN TX", "TARGET"},
+		{"WHOLEFDS MKT 10234 SAN FRANCISCO CA", "WHOLEFDS MKT"},
+		{"AMAZON.COM*2K4L31 AMZN.COM/BILL WA", "AMAZON"},
+		{"POS DEBIT NETFLIX.COM 03/14", "NETFLIX"},
+		{"CHECKCARD 0314 SHELL OIL 57444", "SHELL OIL"},
+		{"PAYPAL *SPOTIFY", "SPOTIFY"},
+		{"UBER TRIP XXXX1234 HELP.UBER.COM", "UBER TRIP"},
+		{"  costco   whse  ", "COSTCO WHSE"},
+		{"NY", "NY"},
+		{"DELTA NY", "DELTA NY"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			if got := normalizePayee(tt.description); got != tt.want {
+				t.Errorf("normalizePayee(%q) = %q, want %q", tt.description, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPayeeKeyMatches(t *testing.T) {
+	tests := []struct {
+		key, payeeKey string
+		want          bool
+	}{
+		{"BLUE BOTTLE", "BLUE BOTTLE", true},
+		{"BLUE BOTTLE COFFEE", "BLUE BOTTLE", true},
+		{"BLUE BOTTLES", "BLUE BOTTLE", false},
+		{"BLUE", "BLUE BOTTLE", false},
+		{"BLUE BOTTLE", "", false},
+	}
+
+	for _, tt := range tests {
+		if got := payeeKeyMatches(tt.key, tt.payeeKey); got != tt.want {
+			t.Errorf("payeeKeyMatches(%q, %q) = %v, want %v", tt.key, tt.payeeKey, got, tt.want)
+		}
+	}
+}
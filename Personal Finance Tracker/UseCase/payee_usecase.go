@@ -0,0 +1,308 @@
+package usecase
+
+import (
+	"errors"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"personal-finance-tracker/domain/entities"
+	repoInterface "personal-finance-tracker/domain/interface"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// maxMatchDescriptions caps one batch of descriptions to match
+const maxMatchDescriptions = 500
+
+var colorRegex = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+
+type PayeeUsecase struct {
+	payeeRepo repoInterface.PayeeRepository
+}
+
+func NewPayeeUsecase(payeeRepo repoInterface.PayeeRepository) *PayeeUsecase {
+	return &PayeeUsecase{
+		payeeRepo: payeeRepo,
+	}
+}
+
+func (u *PayeeUsecase) CreatePayee(userID string, payee *entities.Payee) (*entities.Payee, error) {
+	ownerID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, errors.New("invalid user id")
+	}
+	if err := validatePayee(payee); err != nil {
+		return nil, err
+	}
+
+	aliases := normalizeAliases(payee.Aliases, payee.NormalizedName)
+	directory, err := loadPayeeDirectory(u.payeeRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkPayeeKeys(directory, primitive.NilObjectID, append([]string{payee.NormalizedName}, aliases...)); err != nil {
+		return nil, err
+	}
+
+	payee.ID = primitive.NilObjectID
+	payee.UserID = ownerID
+	payee.Aliases = aliases
+	payee.CreatedAt = time.Now()
+	payee.UpdatedAt = time.Now()
+
+	createdPayee, err := u.payeeRepo.CreatePayee(payee)
+	if err != nil {
+		return nil, errors.New("Failed to create payee: " + err.Error())
+	}
+	return createdPayee, nil
+}
+
+func (u *PayeeUsecase) GetPayee(userID, id string) (*entities.Payee, error) {
+	return u.payeeRepo.GetPayeeByID(id, userID)
+}
+
+func (u *PayeeUsecase) ListPayees(userID string, page entities.PageRequest) (*entities.Page[entities.Payee], error) {
+	payees, err := u.payeeRepo.ListPayees(userID, page)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "invalid") {
+			return nil, err
+		}
+		return nil, errors.New("Database error: " + err.Error())
+	}
+	return payees, nil
+}
+
+func (u *PayeeUsecase) UpdatePayee(userID, id string, input *entities.Payee) (*entities.Payee, error) {
+	payee, err := u.payeeRepo.GetPayeeByID(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validatePayee(input); err != nil {
+		return nil, err
+	}
+	if input.NormalizedName != payee.NormalizedName {
+		directory, err := loadPayeeDirectory(u.payeeRepo, userID)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkPayeeKeys(directory, payee.ID, []string{input.NormalizedName}); err != nil {
+			return nil, err
+		}
+	}
+
+	payee.Name = input.Name
+	payee.NormalizedName = input.NormalizedName
+	payee.DefaultCategory = input.DefaultCategory
+	payee.LogoURL = input.LogoURL
+	payee.Color = input.Color
+	payee.UpdatedAt = time.Now()
+
+	return u.payeeRepo.UpdatePayee(payee)
+}
+
+func (u *PayeeUsecase) DeletePayee(userID, id string) error {
+	return u.payeeRepo.DeletePayee(id, userID)
+}
+
+// AddAlias normalizes the alias the same way descriptions are, so a raw
+// bank description can be passed as is
+func (u *PayeeUsecase) AddAlias(userID, id, alias string) (*entities.Payee, error) {
+	payee, err := u.payeeRepo.GetPayeeByID(id, userID)
+	if err != nil {
+		return nil, err
+	}
+	key := normalizePayee(alias)
+	if key == "" {
+		return nil, errors.New("invalid alias: nothing left after normalization")
+	}
+
+	directory, err := loadPayeeDirectory(u.payeeRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkPayeeKeys(directory, payee.ID, []string{key}); err != nil {
+		return nil, err
+	}
+
+	return u.payeeRepo.AddAliases(id, userID, []string{key})
+}
+
+func (u *PayeeUsecase) RemoveAlias(userID, id, alias string) (*entities.Payee, error) {
+	return u.payeeRepo.RemoveAlias(id, userID, normalizePayee(alias))
+}
+
+// MergePayees folds the source payees into the target: their names and
+// aliases become aliases of the target, blank target metadata is filled in
+// from them, and the sources are deleted
+func (u *PayeeUsecase) MergePayees(userID, targetID string, sourceIDs []string) (*entities.Payee, error) {
+	if len(sourceIDs) == 0 {
+		return nil, errors.New("source_ids is required")
+	}
+	target, err := u.payeeRepo.GetPayeeByID(targetID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var sources []*entities.Payee
+	var keys []string
+	for _, sourceID := range sourceIDs {
+		if sourceID == targetID {
+			return nil, errors.New("cannot merge a payee into itself")
+		}
+		source, err := u.payeeRepo.GetPayeeByID(sourceID, userID)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, source)
+		keys = append(keys, source.NormalizedName)
+		keys = append(keys, source.Aliases...)
+
+		if target.DefaultCategory == "" {
+			target.DefaultCategory = source.DefaultCategory
+		}
+		if target.LogoURL == "" {
+			target.LogoURL = source.LogoURL
+		}
+		if target.Color == "" {
+			target.Color = source.Color
+		}
+	}
+
+	target.UpdatedAt = time.Now()
+	if _, err := u.payeeRepo.UpdatePayee(target); err != nil {
+		return nil, errors.New("Database error: " + err.Error())
+	}
+	merged, err := u.payeeRepo.AddAliases(targetID, userID, normalizeAliases(keys, target.NormalizedName))
+	if err != nil {
+		return nil, errors.New("Database error: " + err.Error())
+	}
+	for _, source := range sources {
+		if err := u.payeeRepo.DeletePayee(source.ID.Hex(), userID); err != nil {
+			return nil, errors.New("Database error: " + err.Error())
+		}
+	}
+
+	return merged, nil
+}
+
+// MatchDescriptions resolves raw bank descriptions to payees. An exact
+// name or alias match wins, otherwise the longest name or alias the
+// description starts with; unmatched descriptions have no payee.
+func (u *PayeeUsecase) MatchDescriptions(userID string, descriptions []string) ([]entities.PayeeMatch, error) {
+	if len(descriptions) == 0 {
+		return nil, errors.New("descriptions is required")
+	}
+	if len(descriptions) > maxMatchDescriptions {
+		return nil, errors.New("too many descriptions: at most 500 per request")
+	}
+
+	directory, err := loadPayeeDirectory(u.payeeRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := make([]entities.PayeeMatch, 0, len(descriptions))
+	for _, description := range descriptions {
+		key := normalizePayee(description)
+		matches = append(matches, entities.PayeeMatch{
+			Description: description,
+			Normalized:  key,
+			Payee:       matchPayee(directory, key),
+		})
+	}
+	return matches, nil
+}
+
+func loadPayeeDirectory(payeeRepo repoInterface.PayeeRepository, userID string) ([]entities.Payee, error) {
+	payees, err := payeeRepo.GetAllPayees(userID)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "invalid") {
+			return nil, err
+		}
+		return nil, errors.New("Database error: " + err.Error())
+	}
+	return payees, nil
+}
+
+func matchPayee(directory []entities.Payee, key string) *entities.Payee {
+	if key == "" {
+		return nil
+	}
+
+	var best *entities.Payee
+	bestLength := 0
+	for i := range directory {
+		payee := &directory[i]
+		for _, payeeKey := range append([]string{payee.NormalizedName}, payee.Aliases...) {
+			if payeeKey == key {
+				return payee
+			}
+			if payeeKeyMatches(key, payeeKey) && len(payeeKey) > bestLength {
+				best = payee
+				bestLength = len(payeeKey)
+			}
+		}
+	}
+	return best
+}
+
+// checkPayeeKeys rejects keys that already name or alias another payee,
+// which would make matching ambiguous
+func checkPayeeKeys(directory []entities.Payee, self primitive.ObjectID, keys []string) error {
+	for _, payee := range directory {
+		if payee.ID == self {
+			continue
+		}
+		for _, key := range keys {
+			if payee.NormalizedName == key {
+				return errors.New("payee already exists: " + payee.Name)
+			}
+			for _, alias := range payee.Aliases {
+				if alias == key {
+					return errors.New("alias " + key + " already belongs to payee " + payee.Name)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// normalizeAliases normalizes and dedupes aliases, dropping empty ones and
+// any equal to the payee's own name
+func normalizeAliases(aliases []string, name string) []string {
+	seen := map[string]bool{name: true}
+	normalized := []string{}
+	for _, alias := range aliases {
+		key := normalizePayee(alias)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		normalized = append(normalized, key)
+	}
+	return normalized
+}
+
+func validatePayee(payee *entities.Payee) error {
+	payee.Name = strings.TrimSpace(payee.Name)
+	if payee.Name == "" {
+		return errors.New("name is required")
+	}
+	payee.NormalizedName = normalizePayee(payee.Name)
+	if payee.NormalizedName == "" {
+		return errors.New("invalid name: nothing left after normalization")
+	}
+	payee.DefaultCategory = strings.TrimSpace(payee.DefaultCategory)
+	if payee.Color != "" && !colorRegex.MatchString(payee.Color) {
+		return errors.New("invalid color: must be #RRGGBB")
+	}
+	if payee.LogoURL != "" {
+		parsed, err := url.Parse(payee.LogoURL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return errors.New("invalid logo_url: must be an http or https url")
+		}
+	}
+	return nil
+}
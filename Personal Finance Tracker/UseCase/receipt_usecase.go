@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"personal-finance-tracker/Infrastructure/service"
+	"personal-finance-tracker/domain/entities"
+	repoInterface "personal-finance-tracker/domain/interface"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ReceiptUsecase struct {
+	receiptRepo       repoInterface.ReceiptRepository
+	payeeRepo         repoInterface.PayeeRepository
+	attachmentUsecase *AttachmentUsecase
+}
+
+func NewReceiptUsecase(receiptRepo repoInterface.ReceiptRepository, payeeRepo repoInterface.PayeeRepository, attachmentUsecase *AttachmentUsecase) *ReceiptUsecase {
+	return &ReceiptUsecase{
+		receiptRepo:       receiptRepo,
+		payeeRepo:         payeeRepo,
+		attachmentUsecase: attachmentUsecase,
+	}
+}
+
+// ParseReceipt reads a forwarded receipt email, matches its merchant
+// against the user's payee directory and keeps the result as a draft. The
+// email itself and the files in it are stored as attachments of the
+// receipt; files of a type attachments don't accept are listed without one.
+func (u *ReceiptUsecase) ParseReceipt(userID string, email io.Reader) (*entities.ParsedReceipt, error) {
+	ownerID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, errors.New("invalid user id")
+	}
+	content, err := io.ReadAll(email)
+	if err != nil {
+		return nil, errors.New("invalid email: " + err.Error())
+	}
+	receipt, err := services.ParseReceiptEmail(bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+
+	if receipt.Merchant != "" {
+		directory, err := loadPayeeDirectory(u.payeeRepo, userID)
+		if err != nil {
+			return nil, err
+		}
+		if receipt.Payee = matchPayee(directory, normalizePayee(receipt.Merchant)); receipt.Payee != nil {
+			receipt.PayeeID = &receipt.Payee.ID
+		}
+	}
+
+	// The files go in first under the receipt's id, so a receipt is never
+	// saved pointing at files that failed to store
+	receipt.ID = primitive.NewObjectID()
+	receipt.UserID = ownerID
+	receipt.CreatedAt = time.Now()
+
+	stored, err := u.attachmentUsecase.store(ownerID, entities.AttachmentParentReceipt, receipt.ID, "receipt.eml", "message/rfc822", content)
+	if err != nil {
+		return nil, err
+	}
+	receipt.EmailAttachmentID = &stored.ID
+
+	for i := range receipt.Attachments {
+		file := &receipt.Attachments[i]
+		contentType := strings.Split(http.DetectContentType(file.Data), ";")[0]
+		if len(file.Data) == 0 || len(file.Data) > MaxAttachmentSize || !attachmentContentTypes[contentType] {
+			log.Printf("⚠️ Receipt file %q not kept: %s, %d bytes", file.Filename, contentType, len(file.Data))
+			continue
+		}
+		stored, err := u.attachmentUsecase.store(ownerID, entities.AttachmentParentReceipt, receipt.ID, file.Filename, contentType, file.Data)
+		if err != nil {
+			u.deleteFiles(userID, receipt.ID.Hex())
+			return nil, err
+		}
+		file.AttachmentID = &stored.ID
+	}
+
+	createdReceipt, err := u.receiptRepo.CreateReceipt(receipt)
+	if err != nil {
+		u.deleteFiles(userID, receipt.ID.Hex())
+		return nil, errors.New("Failed to create receipt: " + err.Error())
+	}
+	return createdReceipt, nil
+}
+
+func (u *ReceiptUsecase) GetReceipt(userID, id string) (*entities.ParsedReceipt, error) {
+	return u.receiptRepo.GetReceiptByID(id, userID)
+}
+
+func (u *ReceiptUsecase) ListReceipts(userID string, page entities.PageRequest) (*entities.Page[entities.ParsedReceipt], error) {
+	receipts, err := u.receiptRepo.ListReceipts(userID, page)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "invalid") {
+			return nil, err
+		}
+		return nil, errors.New("Database error: " + err.Error())
+	}
+	return receipts, nil
+}
+
+func (u *ReceiptUsecase) DeleteReceipt(userID, id string) error {
+	if err := u.receiptRepo.DeleteReceipt(id, userID); err != nil {
+		return err
+	}
+	return u.attachmentUsecase.DeleteParentAttachments(userID, entities.AttachmentParentReceipt, id)
+}
+
+func (u *ReceiptUsecase) deleteFiles(userID, receiptID string) {
+	if err := u.attachmentUsecase.DeleteParentAttachments(userID, entities.AttachmentParentReceipt, receiptID); err != nil {
+		log.Printf("⚠️ Failed to remove files of receipt %s: %v", receiptID, err)
+	}
+}
@@ -0,0 +1,26 @@
+package usecase
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+const secretTokenBytes = 32
+
+// newSecretToken returns a random URL-safe token for links sent to users
+func newSecretToken() (string, error) {
+	secret := make([]byte, secretTokenBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(secret), nil
+}
+
+// hashSecretToken is what gets stored, so a database leak does not leak
+// usable tokens
+func hashSecretToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
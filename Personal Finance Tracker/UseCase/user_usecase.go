@@ -25,6 +25,7 @@ func NewUserUsecase(userRepo repoInterface.UserRepository) *UserUsecase{
 
 type UserInterface interface{
     Register(user *entities.User) (*entities.User, error)
+    ListUsers(page entities.PageRequest) (*entities.Page[entities.User], error)
 }
 
 func (u *UserUsecase) Register(user *entities.User) (*entities.User, error){
@@ -84,4 +85,21 @@ func (u *UserUsecase) Register(user *entities.User) (*entities.User, error){
 	// Don't return password in response
 	createUser.Password = ""
 	return createUser, nil
-}
\ No newline at end of file
+}
+
+// ListUsers returns a page of users for the admin listing
+func (u *UserUsecase) ListUsers(page entities.PageRequest) (*entities.Page[entities.User], error) {
+	users, err := u.userRepo.ListUsers(page)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "invalid") {
+			return nil, err
+		}
+		return nil, errors.New("Database error: " + err.Error())
+	}
+
+	// Don't return passwords in response
+	for i := range users.Items {
+		users.Items[i].Password = ""
+	}
+	return users, nil
+}
@@ -0,0 +1,40 @@
+package entities
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Records an attachment can belong to
+const (
+	AttachmentParentBill              = "bill"
+	AttachmentParentDebt              = "debt"
+	AttachmentParentGoal              = "goal"
+	AttachmentParentInvestmentAccount = "investment_account"
+	AttachmentParentReceipt           = "receipt"
+)
+
+// Attachment describes a receipt or document kept in GridFS. FileID and
+// ThumbnailID point at the stored files and are never exposed; the
+// thumbnail's bytes count toward the quota through ThumbnailSize.
+type Attachment struct {
+	ID            primitive.ObjectID  `bson:"_id,omitempty" json:"id,omitempty"`
+	UserID        primitive.ObjectID  `bson:"user_id" json:"user_id"`
+	ParentType    string              `bson:"parent_type" json:"parent_type"`
+	ParentID      primitive.ObjectID  `bson:"parent_id" json:"parent_id"`
+	Filename      string              `bson:"filename" json:"filename"`
+	ContentType   string              `bson:"content_type" json:"content_type"`
+	Size          int64               `bson:"size" json:"size"`
+	FileID        primitive.ObjectID  `bson:"file_id" json:"-"`
+	ThumbnailID   *primitive.ObjectID `bson:"thumbnail_id,omitempty" json:"-"`
+	ThumbnailSize int64               `bson:"thumbnail_size,omitempty" json:"-"`
+	HasThumbnail  bool                `bson:"-" json:"has_thumbnail"`
+	CreatedAt     time.Time           `bson:"created_at,omitempty" json:"created_at,omitempty"`
+}
+
+// AttachmentUsage is how much of the storage quota a user has taken
+type AttachmentUsage struct {
+	Used  int64 `json:"used"`
+	Quota int64 `json:"quota"`
+}
@@ -0,0 +1,66 @@
+package entities
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	BillFrequencyMonthly   = "monthly"
+	BillFrequencyQuarterly = "quarterly"
+	BillFrequencyAnnually  = "annually"
+)
+
+// Bill statuses reported for a due date
+const (
+	BillStatusUpcoming = "upcoming"
+	BillStatusPaid     = "paid"
+	BillStatusMissed   = "missed"
+)
+
+// Bill is an expected recurring payment. It is due on DueDay of every
+// period from StartDate, or on the last day of shorter months.
+type Bill struct {
+	ID                 primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	UserID             primitive.ObjectID `bson:"user_id" json:"user_id"`
+	Payee              string             `bson:"payee" json:"payee"`
+	Category           string             `bson:"category,omitempty" json:"category,omitempty"`
+	AmountMin          float64            `bson:"amount_min" json:"amount_min"`
+	AmountMax          float64            `bson:"amount_max" json:"amount_max"`
+	Currency           string             `bson:"currency,omitempty" json:"currency,omitempty"`
+	DueDay             int                `bson:"due_day" json:"due_day"`
+	Frequency          string             `bson:"frequency" json:"frequency"`
+	StartDate          time.Time          `bson:"start_date" json:"start_date"`
+	Autopay            bool               `bson:"autopay" json:"autopay"`
+	ReminderDaysBefore int                `bson:"reminder_days_before" json:"reminder_days_before"`
+	Active             bool               `bson:"active" json:"active"`
+	Notes              string             `bson:"notes,omitempty" json:"notes,omitempty"`
+	Payments           []BillPayment      `bson:"payments" json:"payments"`
+	LastRemindedDue    *time.Time         `bson:"last_reminded_due,omitempty" json:"last_reminded_due,omitempty"`
+	CreatedAt          time.Time          `bson:"created_at,omitempty" json:"created_at,omitempty"`
+	UpdatedAt          time.Time          `bson:"updated_at,omitempty" json:"updated_at,omitempty"`
+}
+
+// BillPayment records that a bill was paid. DueDate is the due date the
+// payment settles; Unusual is set when the amount is above the expected range.
+type BillPayment struct {
+	ID      primitive.ObjectID `bson:"_id" json:"id"`
+	Date    time.Time          `bson:"date" json:"date"`
+	Amount  float64            `bson:"amount" json:"amount"`
+	DueDate time.Time          `bson:"due_date" json:"due_date"`
+	Unusual bool               `bson:"unusual" json:"unusual"`
+}
+
+// BillOccurrence is one due date of a bill
+type BillOccurrence struct {
+	BillID    primitive.ObjectID `json:"bill_id"`
+	Payee     string             `json:"payee"`
+	DueDate   time.Time          `json:"due_date"`
+	AmountMin float64            `json:"amount_min"`
+	AmountMax float64            `json:"amount_max"`
+	Currency  string             `json:"currency,omitempty"`
+	Autopay   bool               `json:"autopay"`
+	Status    string             `json:"status"`
+	Payment   *BillPayment       `json:"payment,omitempty"`
+}
@@ -0,0 +1,26 @@
+package entities
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CalendarFeed grants read access to a user's ICS feed. Only a hash of the
+// secret token is stored; the token itself is shown once when created.
+type CalendarFeed struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	UserID    primitive.ObjectID `bson:"user_id" json:"user_id"`
+	TokenHash string             `bson:"token_hash" json:"-"`
+	CreatedAt time.Time          `bson:"created_at,omitempty" json:"created_at,omitempty"`
+}
+
+// CalendarEvent is one all-day entry of a calendar feed
+type CalendarEvent struct {
+	UID         string
+	Summary     string
+	Description string
+	Date        time.Time
+	// AlarmDaysBefore is when the calendar app should alert; zero adds no alarm
+	AlarmDaysBefore int
+}
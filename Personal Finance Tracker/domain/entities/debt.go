@@ -0,0 +1,97 @@
+package entities
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	DebtTypeMortgage     = "mortgage"
+	DebtTypeCarLoan      = "car_loan"
+	DebtTypeCreditCard   = "credit_card"
+	DebtTypeStudentLoan  = "student_loan"
+	DebtTypePersonalLoan = "personal_loan"
+	DebtTypeOther        = "other"
+)
+
+// Compounding and payment frequencies
+const (
+	FrequencyDaily    = "daily"
+	FrequencyWeekly   = "weekly"
+	FrequencyBiweekly = "biweekly"
+	FrequencyMonthly  = "monthly"
+	FrequencyAnnually = "annually"
+)
+
+// Debt is a liability such as a loan or credit card balance.
+// APR is a yearly percentage, e.g. 6.5 for 6.5%.
+type Debt struct {
+	ID               primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	UserID           primitive.ObjectID `bson:"user_id" json:"user_id"`
+	Name             string             `bson:"name" json:"name"`
+	Type             string             `bson:"type" json:"type"`
+	Lender           string             `bson:"lender,omitempty" json:"lender,omitempty"`
+	Currency         string             `bson:"currency,omitempty" json:"currency,omitempty"`
+	Principal        float64            `bson:"principal" json:"principal"`
+	CurrentBalance   float64            `bson:"current_balance" json:"current_balance"`
+	APR              float64            `bson:"apr" json:"apr"`
+	Compounding      string             `bson:"compounding" json:"compounding"`
+	PaymentFrequency string             `bson:"payment_frequency" json:"payment_frequency"`
+	PaymentAmount    float64            `bson:"payment_amount" json:"payment_amount"`
+	TermMonths       int                `bson:"term_months,omitempty" json:"term_months,omitempty"`
+	StartDate        time.Time          `bson:"start_date" json:"start_date"`
+	ExtraPayments    []ExtraPayment     `bson:"extra_payments" json:"extra_payments"`
+	CreatedAt        time.Time          `bson:"created_at,omitempty" json:"created_at,omitempty"`
+	UpdatedAt        time.Time          `bson:"updated_at,omitempty" json:"updated_at,omitempty"`
+}
+
+// ExtraPayment is a payment on top of the schedule. Recurring extra
+// payments are added to every scheduled payment from Date onwards.
+type ExtraPayment struct {
+	ID        primitive.ObjectID `bson:"_id" json:"id"`
+	Amount    float64            `bson:"amount" json:"amount"`
+	Date      time.Time          `bson:"date" json:"date"`
+	Recurring bool               `bson:"recurring" json:"recurring"`
+}
+
+type AmortizationRow struct {
+	Number    int       `json:"number"`
+	Date      time.Time `json:"date"`
+	Payment   float64   `json:"payment"`
+	Principal float64   `json:"principal"`
+	Interest  float64   `json:"interest"`
+	Extra     float64   `json:"extra"`
+	Balance   float64   `json:"balance"`
+}
+
+type AmortizationSchedule struct {
+	DebtID        primitive.ObjectID `json:"debt_id"`
+	Rows          []AmortizationRow  `json:"rows"`
+	TotalPaid     float64            `json:"total_paid"`
+	TotalInterest float64            `json:"total_interest"`
+	PayoffDate    time.Time          `json:"payoff_date"`
+}
+
+const (
+	PayoffStrategySnowball  = "snowball"
+	PayoffStrategyAvalanche = "avalanche"
+)
+
+// PayoffPlan is the outcome of paying all debts down with one strategy
+type PayoffPlan struct {
+	Strategy       string       `json:"strategy"`
+	MonthlyBudget  float64      `json:"monthly_budget"`
+	MonthsToPayoff int          `json:"months_to_payoff"`
+	TotalInterest  float64      `json:"total_interest"`
+	TotalPaid      float64      `json:"total_paid"`
+	PayoffDate     time.Time    `json:"payoff_date"`
+	Order          []DebtPayoff `json:"order"`
+}
+
+type DebtPayoff struct {
+	DebtID       primitive.ObjectID `json:"debt_id"`
+	Name         string             `json:"name"`
+	PaidOffMonth int                `json:"paid_off_month"`
+	InterestPaid float64            `json:"interest_paid"`
+}
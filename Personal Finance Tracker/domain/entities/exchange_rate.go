@@ -0,0 +1,31 @@
+package entities
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ECBBaseCurrency is the base of every rate published by the European Central Bank
+const ECBBaseCurrency = "EUR"
+
+// ExchangeRate is the daily rate of one unit of Base expressed in Quote
+type ExchangeRate struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	Base      string             `bson:"base" json:"base"`
+	Quote     string             `bson:"quote" json:"quote"`
+	Date      time.Time          `bson:"date" json:"date"`
+	Rate      float64            `bson:"rate" json:"rate"`
+	Source    string             `bson:"source,omitempty" json:"source,omitempty"`
+	CreatedAt time.Time          `bson:"created_at,omitempty" json:"created_at,omitempty"`
+}
+
+// Conversion is the result of converting an amount between two currencies
+type Conversion struct {
+	Amount    float64   `json:"amount"`
+	From      string    `json:"from"`
+	To        string    `json:"to"`
+	Date      time.Time `json:"date"`
+	Rate      float64   `json:"rate"`
+	Converted float64   `json:"converted"`
+}
@@ -0,0 +1,111 @@
+package entities
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Ways an expense is divided among participants
+const (
+	SplitEqual      = "equal"
+	SplitExact      = "exact"
+	SplitPercentage = "percentage"
+	SplitShares     = "shares"
+)
+
+// Kinds of group expense. A settlement is a payment between two
+// participants, recorded as an expense paid by one and owed by the other.
+const (
+	GroupExpenseKindExpense    = "expense"
+	GroupExpenseKindSettlement = "settlement"
+)
+
+// ExpenseGroup is a set of people sharing costs, such as a trip or a team
+// lunch. Registered participants can see and edit the group.
+type ExpenseGroup struct {
+	ID           primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	Name         string             `bson:"name" json:"name"`
+	Currency     string             `bson:"currency,omitempty" json:"currency,omitempty"`
+	Participants []Participant      `bson:"participants" json:"participants"`
+	CreatedBy    primitive.ObjectID `bson:"created_by" json:"created_by"`
+	CreatedAt    time.Time          `bson:"created_at,omitempty" json:"created_at,omitempty"`
+	UpdatedAt    time.Time          `bson:"updated_at,omitempty" json:"updated_at,omitempty"`
+}
+
+// Participant is a registered user when UserID is set, otherwise a
+// lightweight contact known only by name and email
+type Participant struct {
+	ID     primitive.ObjectID  `bson:"_id" json:"id"`
+	Name   string              `bson:"name" json:"name"`
+	Email  string              `bson:"email,omitempty" json:"email,omitempty"`
+	UserID *primitive.ObjectID `bson:"user_id,omitempty" json:"user_id,omitempty"`
+}
+
+// Participant returns the participant with the id, or nil
+func (g *ExpenseGroup) Participant(id primitive.ObjectID) *Participant {
+	for i := range g.Participants {
+		if g.Participants[i].ID == id {
+			return &g.Participants[i]
+		}
+	}
+	return nil
+}
+
+// ParticipantForUser returns the participant linked to the user, or nil
+func (g *ExpenseGroup) ParticipantForUser(userID primitive.ObjectID) *Participant {
+	for i := range g.Participants {
+		if g.Participants[i].UserID != nil && *g.Participants[i].UserID == userID {
+			return &g.Participants[i]
+		}
+	}
+	return nil
+}
+
+type GroupExpense struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	GroupID     primitive.ObjectID `bson:"group_id" json:"group_id"`
+	Kind        string             `bson:"kind" json:"kind"`
+	Description string             `bson:"description" json:"description"`
+	Amount      float64            `bson:"amount" json:"amount"`
+	Date        time.Time          `bson:"date" json:"date"`
+	PaidBy      primitive.ObjectID `bson:"paid_by" json:"paid_by"`
+	SplitMethod string             `bson:"split_method" json:"split_method"`
+	Splits      []ExpenseSplit     `bson:"splits" json:"splits"`
+	CreatedBy   primitive.ObjectID `bson:"created_by" json:"created_by"`
+	CreatedAt   time.Time          `bson:"created_at,omitempty" json:"created_at,omitempty"`
+}
+
+// ExpenseSplit is one participant's part of an expense. Value is the input
+// for the split method (an amount, a percentage or a number of shares,
+// unused for equal splits); Amount is what the participant owes.
+type ExpenseSplit struct {
+	ParticipantID primitive.ObjectID `bson:"participant_id" json:"participant_id"`
+	Value         float64            `bson:"value,omitempty" json:"value,omitempty"`
+	Amount        float64            `bson:"amount" json:"amount"`
+}
+
+// GroupBalances is where everyone stands and the fewest payments that
+// would settle the group
+type GroupBalances struct {
+	Currency string               `json:"currency,omitempty"`
+	Balances []ParticipantBalance `json:"balances"`
+	SettleUp []SuggestedPayment   `json:"settle_up"`
+}
+
+// ParticipantBalance is positive when the participant is owed money
+type ParticipantBalance struct {
+	ParticipantID primitive.ObjectID `json:"participant_id"`
+	Name          string             `json:"name"`
+	Paid          float64            `json:"paid"`
+	Owed          float64            `json:"owed"`
+	Net           float64            `json:"net"`
+}
+
+type SuggestedPayment struct {
+	From     primitive.ObjectID `json:"from"`
+	FromName string             `json:"from_name"`
+	To       primitive.ObjectID `json:"to"`
+	ToName   string             `json:"to_name"`
+	Amount   float64            `json:"amount"`
+}
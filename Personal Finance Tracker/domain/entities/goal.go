@@ -0,0 +1,55 @@
+package entities
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Goal is a savings target funded by contributions over time.
+// Contributions are a virtual allocation tracked on the goal itself.
+type Goal struct {
+	ID     primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	UserID primitive.ObjectID `bson:"user_id" json:"user_id"`
+	// HouseholdID shares the goal: viewers of the household can read it and
+	// editors can change it
+	HouseholdID   *primitive.ObjectID `bson:"household_id,omitempty" json:"household_id,omitempty"`
+	Name          string              `bson:"name" json:"name"`
+	TargetAmount  float64             `bson:"target_amount" json:"target_amount"`
+	Currency      string              `bson:"currency,omitempty" json:"currency,omitempty"`
+	TargetDate    *time.Time          `bson:"target_date,omitempty" json:"target_date,omitempty"`
+	Priority      int                 `bson:"priority" json:"priority"`
+	Notes         string              `bson:"notes,omitempty" json:"notes,omitempty"`
+	Contributions []Contribution      `bson:"contributions" json:"contributions"`
+	CreatedAt     time.Time           `bson:"created_at,omitempty" json:"created_at,omitempty"`
+	UpdatedAt     time.Time           `bson:"updated_at,omitempty" json:"updated_at,omitempty"`
+}
+
+type Contribution struct {
+	ID     primitive.ObjectID `bson:"_id" json:"id"`
+	Amount float64            `bson:"amount" json:"amount"`
+	Date   time.Time          `bson:"date" json:"date"`
+	Note   string             `bson:"note,omitempty" json:"note,omitempty"`
+}
+
+// CurrentAmount sums the contributions made so far; withdrawals are negative
+func (g *Goal) CurrentAmount() float64 {
+	total := 0.0
+	for _, contribution := range g.Contributions {
+		total += contribution.Amount
+	}
+	return total
+}
+
+// GoalProjection estimates when a goal will be reached at the average contribution rate
+type GoalProjection struct {
+	GoalID                      primitive.ObjectID `json:"goal_id"`
+	CurrentAmount               float64            `json:"current_amount"`
+	RemainingAmount             float64            `json:"remaining_amount"`
+	Progress                    float64            `json:"progress"`
+	AverageMonthlyContribution  float64            `json:"average_monthly_contribution"`
+	ProjectedCompletionDate     *time.Time         `json:"projected_completion_date,omitempty"`
+	RequiredMonthlyContribution float64            `json:"required_monthly_contribution,omitempty"`
+	OnTrack                     bool               `json:"on_track"`
+	Completed                   bool               `json:"completed"`
+}
@@ -0,0 +1,63 @@
+package entities
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Household roles. Owners manage members and invitations, editors change
+// shared data and viewers only read it.
+const (
+	HouseholdRoleOwner  = "owner"
+	HouseholdRoleEditor = "editor"
+	HouseholdRoleViewer = "viewer"
+)
+
+// Household groups users who share finances, such as couples or roommates
+type Household struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	Name      string             `bson:"name" json:"name"`
+	Members   []HouseholdMember  `bson:"members" json:"members"`
+	CreatedBy primitive.ObjectID `bson:"created_by" json:"created_by"`
+	CreatedAt time.Time          `bson:"created_at,omitempty" json:"created_at,omitempty"`
+	UpdatedAt time.Time          `bson:"updated_at,omitempty" json:"updated_at,omitempty"`
+}
+
+type HouseholdMember struct {
+	UserID   primitive.ObjectID `bson:"user_id" json:"user_id"`
+	Role     string             `bson:"role" json:"role"`
+	JoinedAt time.Time          `bson:"joined_at" json:"joined_at"`
+}
+
+// Member returns the membership of the user, or nil if they are not a member
+func (h *Household) Member(userID primitive.ObjectID) *HouseholdMember {
+	for i := range h.Members {
+		if h.Members[i].UserID == userID {
+			return &h.Members[i]
+		}
+	}
+	return nil
+}
+
+// HouseholdInvitation is a pending invitation sent by email. Only a hash of
+// the emailed token is stored.
+type HouseholdInvitation struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	HouseholdID primitive.ObjectID `bson:"household_id" json:"household_id"`
+	Email       string             `bson:"email" json:"email"`
+	Role        string             `bson:"role" json:"role"`
+	TokenHash   string             `bson:"token_hash" json:"-"`
+	InvitedBy   primitive.ObjectID `bson:"invited_by" json:"invited_by"`
+	ExpiresAt   time.Time          `bson:"expires_at" json:"expires_at"`
+	CreatedAt   time.Time          `bson:"created_at,omitempty" json:"created_at,omitempty"`
+}
+
+// HouseholdInvitationPreview is what the emailed link shows, before the
+// invitee has signed in
+type HouseholdInvitationPreview struct {
+	HouseholdName string    `json:"household_name"`
+	Email         string    `json:"email"`
+	Role          string    `json:"role"`
+	ExpiresAt     time.Time `json:"expires_at"`
+}
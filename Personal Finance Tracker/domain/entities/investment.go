@@ -0,0 +1,156 @@
+package entities
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Cost basis methods used to pick the lots a sale is taken from
+const (
+	CostBasisFIFO        = "fifo"
+	CostBasisLIFO        = "lifo"
+	CostBasisAverage     = "average"
+	CostBasisSpecificLot = "specific"
+)
+
+const (
+	InvestmentEventBuy      = "buy"
+	InvestmentEventSell     = "sell"
+	InvestmentEventDividend = "dividend"
+	InvestmentEventSplit    = "split"
+)
+
+// InvestmentAccount is a brokerage or retirement account holding securities
+type InvestmentAccount struct {
+	ID              primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	UserID          primitive.ObjectID `bson:"user_id" json:"user_id"`
+	Name            string             `bson:"name" json:"name"`
+	Broker          string             `bson:"broker,omitempty" json:"broker,omitempty"`
+	Currency        string             `bson:"currency,omitempty" json:"currency,omitempty"`
+	CostBasisMethod string             `bson:"cost_basis_method" json:"cost_basis_method"`
+	CreatedAt       time.Time          `bson:"created_at,omitempty" json:"created_at,omitempty"`
+	UpdatedAt       time.Time          `bson:"updated_at,omitempty" json:"updated_at,omitempty"`
+}
+
+// InvestmentEvent is a buy, sell, dividend or split of one security.
+// Price is per share; a split of SplitRatio 2 turns one share into two.
+// Dividends record the cash received in Amount.
+type InvestmentEvent struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	UserID     primitive.ObjectID `bson:"user_id" json:"user_id"`
+	AccountID  primitive.ObjectID `bson:"account_id" json:"account_id"`
+	Symbol     string             `bson:"symbol" json:"symbol"`
+	Type       string             `bson:"type" json:"type"`
+	Date       time.Time          `bson:"date" json:"date"`
+	Quantity   float64            `bson:"quantity,omitempty" json:"quantity,omitempty"`
+	Price      float64            `bson:"price,omitempty" json:"price,omitempty"`
+	Fees       float64            `bson:"fees,omitempty" json:"fees,omitempty"`
+	Amount     float64            `bson:"amount,omitempty" json:"amount,omitempty"`
+	SplitRatio float64            `bson:"split_ratio,omitempty" json:"split_ratio,omitempty"`
+	Lots       []LotSelection     `bson:"lots,omitempty" json:"lots,omitempty"`
+	Notes      string             `bson:"notes,omitempty" json:"notes,omitempty"`
+	CreatedAt  time.Time          `bson:"created_at,omitempty" json:"created_at,omitempty"`
+}
+
+// LotSelection picks how many shares of a lot a sale uses, for specific-lot
+// cost basis. LotID is the id of the buy that opened the lot.
+type LotSelection struct {
+	LotID    primitive.ObjectID `bson:"lot_id" json:"lot_id"`
+	Quantity float64            `bson:"quantity" json:"quantity"`
+}
+
+// TaxLot is the open remainder of one buy
+type TaxLot struct {
+	LotID        primitive.ObjectID `json:"lot_id"`
+	Symbol       string             `json:"symbol"`
+	AcquiredAt   time.Time          `json:"acquired_at"`
+	Quantity     float64            `json:"quantity"`
+	CostPerShare float64            `json:"cost_per_share"`
+	CostBasis    float64            `json:"cost_basis"`
+}
+
+type RealizedGain struct {
+	EventID   primitive.ObjectID `json:"event_id"`
+	Symbol    string             `json:"symbol"`
+	Date      time.Time          `json:"date"`
+	Quantity  float64            `json:"quantity"`
+	Proceeds  float64            `json:"proceeds"`
+	CostBasis float64            `json:"cost_basis"`
+	Gain      float64            `json:"gain"`
+	LongTerm  bool               `json:"long_term"`
+}
+
+type Holding struct {
+	Symbol         string     `json:"symbol"`
+	Quantity       float64    `json:"quantity"`
+	CostBasis      float64    `json:"cost_basis"`
+	AverageCost    float64    `json:"average_cost"`
+	Price          float64    `json:"price,omitempty"`
+	PriceDate      *time.Time `json:"price_date,omitempty"`
+	MarketValue    float64    `json:"market_value,omitempty"`
+	UnrealizedGain float64    `json:"unrealized_gain,omitempty"`
+	Lots           []TaxLot   `json:"lots"`
+}
+
+type HoldingsReport struct {
+	AccountID           primitive.ObjectID `json:"account_id"`
+	AsOf                time.Time          `json:"as_of"`
+	Holdings            []Holding          `json:"holdings"`
+	TotalCostBasis      float64            `json:"total_cost_basis"`
+	TotalMarketValue    float64            `json:"total_market_value"`
+	TotalUnrealizedGain float64            `json:"total_unrealized_gain"`
+	MissingPrices       []string           `json:"missing_prices,omitempty"`
+}
+
+type RealizedGainsReport struct {
+	AccountID     primitive.ObjectID `json:"account_id"`
+	From          time.Time          `json:"from"`
+	To            time.Time          `json:"to"`
+	Gains         []RealizedGain     `json:"gains"`
+	TotalGain     float64            `json:"total_gain"`
+	ShortTermGain float64            `json:"short_term_gain"`
+	LongTermGain  float64            `json:"long_term_gain"`
+	Dividends     float64            `json:"dividends"`
+}
+
+// SecurityPrice is a closing price from the locally imported price history
+type SecurityPrice struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	Symbol    string             `bson:"symbol" json:"symbol"`
+	Date      time.Time          `bson:"date" json:"date"`
+	Close     float64            `bson:"close" json:"close"`
+	CreatedAt time.Time          `bson:"created_at,omitempty" json:"created_at,omitempty"`
+}
+
+// Performance scopes
+const (
+	PerformanceScopeAccount   = "account"
+	PerformanceScopePortfolio = "portfolio"
+)
+
+// PerformanceResult holds the returns of an account or the whole portfolio
+// over one period. TWR ignores the timing of contributions; MWR is the XIRR
+// of the investor's cash flows and rewards good timing. Both are fractions,
+// e.g. 0.05 for 5%. MWR is annualized, TWR is given for the period and
+// annualized for periods of a year or more.
+type PerformanceResult struct {
+	ID               primitive.ObjectID  `bson:"_id,omitempty" json:"-"`
+	UserID           primitive.ObjectID  `bson:"user_id" json:"-"`
+	Scope            string              `bson:"scope" json:"scope"`
+	AccountID        *primitive.ObjectID `bson:"account_id,omitempty" json:"account_id,omitempty"`
+	From             time.Time           `bson:"from" json:"from"`
+	To               time.Time           `bson:"to" json:"to"`
+	StartValue       float64             `bson:"start_value" json:"start_value"`
+	EndValue         float64             `bson:"end_value" json:"end_value"`
+	NetContributions float64             `bson:"net_contributions" json:"net_contributions"`
+	TWR              *float64            `bson:"twr,omitempty" json:"twr"`
+	AnnualizedTWR    *float64            `bson:"annualized_twr,omitempty" json:"annualized_twr,omitempty"`
+	MWR              *float64            `bson:"mwr,omitempty" json:"mwr"`
+	ComputedAt       time.Time           `bson:"computed_at" json:"computed_at"`
+}
+
+type PerformanceReport struct {
+	Portfolio *PerformanceResult  `json:"portfolio,omitempty"`
+	Accounts  []PerformanceResult `json:"accounts"`
+}
@@ -0,0 +1,120 @@
+package entities
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	InvoiceStatusDraft         = "draft"
+	InvoiceStatusSent          = "sent"
+	InvoiceStatusPartiallyPaid = "partially_paid"
+	InvoiceStatusPaid          = "paid"
+	InvoiceStatusVoid          = "void"
+)
+
+// How an incoming payment was matched to an invoice
+const (
+	InvoiceMatchByNumber = "number"
+	InvoiceMatchByAmount = "amount"
+)
+
+// Invoice is a bill sent to a client. Drafts have no number; issuing an
+// invoice gives it the user's next sequential number and freezes its
+// contents. Totals are computed from the line items. SentAt is only set
+// once the invoice has been emailed, so an issued invoice without it was
+// never delivered.
+type Invoice struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	UserID     primitive.ObjectID `bson:"user_id" json:"user_id"`
+	Number     string             `bson:"number,omitempty" json:"number,omitempty"`
+	Status     string             `bson:"status" json:"status"`
+	Client     InvoiceClient      `bson:"client" json:"client"`
+	Currency   string             `bson:"currency" json:"currency"`
+	IssueDate  time.Time          `bson:"issue_date" json:"issue_date"`
+	DueDate    time.Time          `bson:"due_date" json:"due_date"`
+	Items      []InvoiceLineItem  `bson:"items" json:"items"`
+	TaxLines   []InvoiceTaxLine   `bson:"tax_lines" json:"tax_lines"`
+	Subtotal   float64            `bson:"subtotal" json:"subtotal"`
+	TaxTotal   float64            `bson:"tax_total" json:"tax_total"`
+	Total      float64            `bson:"total" json:"total"`
+	AmountPaid float64            `bson:"amount_paid" json:"amount_paid"`
+	AmountDue  float64            `bson:"amount_due" json:"amount_due"`
+	Payments   []InvoicePayment   `bson:"payments" json:"payments"`
+	Notes      string             `bson:"notes,omitempty" json:"notes,omitempty"`
+	Overdue    bool               `bson:"-" json:"overdue"`
+	SentAt     *time.Time         `bson:"sent_at,omitempty" json:"sent_at,omitempty"`
+	PaidAt     *time.Time         `bson:"paid_at,omitempty" json:"paid_at,omitempty"`
+	CreatedAt  time.Time          `bson:"created_at,omitempty" json:"created_at,omitempty"`
+	UpdatedAt  time.Time          `bson:"updated_at,omitempty" json:"updated_at,omitempty"`
+}
+
+type InvoiceClient struct {
+	Name    string `bson:"name" json:"name"`
+	Email   string `bson:"email,omitempty" json:"email,omitempty"`
+	Address string `bson:"address,omitempty" json:"address,omitempty"`
+}
+
+// InvoiceLineItem is one billed line. TaxRate is a percentage; Net and Tax
+// are computed.
+type InvoiceLineItem struct {
+	Description string  `bson:"description" json:"description"`
+	Quantity    float64 `bson:"quantity" json:"quantity"`
+	UnitPrice   float64 `bson:"unit_price" json:"unit_price"`
+	TaxRate     float64 `bson:"tax_rate" json:"tax_rate"`
+	Net         float64 `bson:"net" json:"net"`
+	Tax         float64 `bson:"tax" json:"tax"`
+}
+
+// InvoiceTaxLine totals the items charged at one tax rate
+type InvoiceTaxLine struct {
+	Rate float64 `bson:"rate" json:"rate"`
+	Net  float64 `bson:"net" json:"net"`
+	Tax  float64 `bson:"tax" json:"tax"`
+}
+
+// InvoicePayment is money received against an invoice. MatchedBy is set
+// when the payment was matched automatically.
+type InvoicePayment struct {
+	ID        primitive.ObjectID `bson:"_id" json:"id"`
+	Date      time.Time          `bson:"date" json:"date"`
+	Amount    float64            `bson:"amount" json:"amount"`
+	Reference string             `bson:"reference,omitempty" json:"reference,omitempty"`
+	MatchedBy string             `bson:"matched_by,omitempty" json:"matched_by,omitempty"`
+}
+
+// IncomingPayment is a received payment to be matched to an open invoice
+type IncomingPayment struct {
+	Amount    float64   `json:"amount"`
+	Currency  string    `json:"currency"`
+	Date      time.Time `json:"date"`
+	Reference string    `json:"reference"`
+}
+
+// InvoiceMatch is the outcome of matching an incoming payment. When no
+// single invoice matches, Candidates lists the open invoices that could.
+type InvoiceMatch struct {
+	Matched    bool      `json:"matched"`
+	MatchedBy  string    `json:"matched_by,omitempty"`
+	Invoice    *Invoice  `json:"invoice,omitempty"`
+	Candidates []Invoice `json:"candidates"`
+}
+
+// Receivables sums what clients still owe, per currency
+type Receivables struct {
+	Totals   []ReceivablesTotal `json:"totals"`
+	Invoices []Invoice          `json:"invoices"`
+}
+
+// ReceivablesTotal ages the outstanding amount of one currency by days
+// past the due date
+type ReceivablesTotal struct {
+	Currency      string  `json:"currency"`
+	Outstanding   float64 `json:"outstanding"`
+	NotYetDue     float64 `json:"not_yet_due"`
+	Overdue1To30  float64 `json:"overdue_1_30"`
+	Overdue31To60 float64 `json:"overdue_31_60"`
+	Overdue61To90 float64 `json:"overdue_61_90"`
+	OverdueOver90 float64 `json:"overdue_over_90"`
+}
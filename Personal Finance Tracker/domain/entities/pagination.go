@@ -0,0 +1,23 @@
+package entities
+
+const (
+	DefaultPageLimit int64 = 20
+	MaxPageLimit     int64 = 100
+)
+
+// PageRequest is the shared pagination contract for list endpoints.
+// Sort is a field name, prefixed with "-" for descending order.
+// Cursor is the opaque value returned as NextCursor by the previous page.
+type PageRequest struct {
+	Limit  int64
+	Cursor string
+	Sort   string
+	Fields []string
+}
+
+// Page holds one page of results and the cursor for the next one
+type Page[T any] struct {
+	Items      []T    `json:"items"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	HasMore    bool   `json:"has_more"`
+}
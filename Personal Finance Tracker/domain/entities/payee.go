@@ -0,0 +1,29 @@
+package entities
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Payee is a merchant or person money is paid to. Raw bank descriptions
+// are matched to it through its normalized name and aliases.
+type Payee struct {
+	ID              primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	UserID          primitive.ObjectID `bson:"user_id" json:"user_id"`
+	Name            string             `bson:"name" json:"name"`
+	NormalizedName  string             `bson:"normalized_name" json:"normalized_name"`
+	Aliases         []string           `bson:"aliases" json:"aliases"`
+	DefaultCategory string             `bson:"default_category,omitempty" json:"default_category,omitempty"`
+	LogoURL         string             `bson:"logo_url,omitempty" json:"logo_url,omitempty"`
+	Color           string             `bson:"color,omitempty" json:"color,omitempty"`
+	CreatedAt       time.Time          `bson:"created_at,omitempty" json:"created_at,omitempty"`
+	UpdatedAt       time.Time          `bson:"updated_at,omitempty" json:"updated_at,omitempty"`
+}
+
+// PayeeMatch is the result of matching a raw description to the directory
+type PayeeMatch struct {
+	Description string `json:"description"`
+	Normalized  string `json:"normalized"`
+	Payee       *Payee `json:"payee"`
+}
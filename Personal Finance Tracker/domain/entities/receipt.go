@@ -0,0 +1,36 @@
+package entities
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ParsedReceipt is what could be read from a forwarded receipt email.
+// Fields that were not found are left empty. It is kept as a draft, with
+// the email and its files stored as attachments of the receipt.
+type ParsedReceipt struct {
+	ID                primitive.ObjectID  `bson:"_id,omitempty" json:"id,omitempty"`
+	UserID            primitive.ObjectID  `bson:"user_id" json:"user_id"`
+	Subject           string              `bson:"subject" json:"subject"`
+	From              string              `bson:"from" json:"from"`
+	Merchant          string              `bson:"merchant,omitempty" json:"merchant,omitempty"`
+	Amount            *float64            `bson:"amount,omitempty" json:"amount,omitempty"`
+	Currency          string              `bson:"currency,omitempty" json:"currency,omitempty"`
+	Date              *time.Time          `bson:"date,omitempty" json:"date,omitempty"`
+	EmailAttachmentID *primitive.ObjectID `bson:"email_attachment_id,omitempty" json:"email_attachment_id,omitempty"`
+	Attachments       []ReceiptAttachment `bson:"attachments" json:"attachments"`
+	PayeeID           *primitive.ObjectID `bson:"payee_id,omitempty" json:"payee_id,omitempty"`
+	Payee             *Payee              `bson:"-" json:"payee,omitempty"`
+	CreatedAt         time.Time           `bson:"created_at,omitempty" json:"created_at,omitempty"`
+}
+
+// ReceiptAttachment is a file found in a receipt email. AttachmentID is
+// missing when the file's type can't be kept as an attachment.
+type ReceiptAttachment struct {
+	Filename     string              `bson:"filename" json:"filename"`
+	ContentType  string              `bson:"content_type" json:"content_type"`
+	Size         int                 `bson:"size" json:"size"`
+	AttachmentID *primitive.ObjectID `bson:"attachment_id,omitempty" json:"attachment_id,omitempty"`
+	Data         []byte              `bson:"-" json:"-"`
+}
@@ -9,7 +9,7 @@ import (
 type User struct {
 	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
 	Email      string             `bson:"email,omitempty" json:"email"`
-	Password   string             `bson:"password,omitempty" json:"password"`
+	Password   string             `bson:"password,omitempty" json:"password,omitempty"`
 	Name       string             `bson:"name,omitempty" json:"name,omitempty"`
 	Role       string             `bson:"role,omitempty" json:"role,omitempty"`
 	Currency   string             `bson:"currency,omitempty" json:"currency,omitempty"`
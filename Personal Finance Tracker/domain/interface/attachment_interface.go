@@ -0,0 +1,29 @@
+package repositories
+
+import (
+	"io"
+
+	"personal-finance-tracker/domain/entities"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type AttachmentRepository interface {
+	CreateAttachment(attachment *entities.Attachment) (*entities.Attachment, error)
+	GetAttachmentByID(id string, userID string) (*entities.Attachment, error)
+	ListAttachments(userID string, parentType string, parentID string, page entities.PageRequest) (*entities.Page[entities.Attachment], error)
+	DeleteAttachment(id string, userID string) error
+	// DeleteAttachmentsByParent removes every attachment of one parent record
+	// and returns them, so their files can be removed too
+	DeleteAttachmentsByParent(userID string, parentType string, parentID string) ([]entities.Attachment, error)
+	// TotalSize sums the size of every file the user has stored, thumbnails
+	// included
+	TotalSize(userID string) (int64, error)
+}
+
+// FileStore keeps file contents, addressed by the id returned on upload
+type FileStore interface {
+	Upload(filename string, contentType string, data io.Reader) (primitive.ObjectID, error)
+	Open(id primitive.ObjectID) (io.ReadCloser, error)
+	Delete(id primitive.ObjectID) error
+}
@@ -0,0 +1,20 @@
+package repositories
+
+import (
+	"time"
+
+	"personal-finance-tracker/domain/entities"
+)
+
+type BillRepository interface {
+	CreateBill(bill *entities.Bill) (*entities.Bill, error)
+	GetBillByID(id string, userID string) (*entities.Bill, error)
+	ListBills(userID string, page entities.PageRequest) (*entities.Page[entities.Bill], error)
+	GetActiveBills(userID string) ([]entities.Bill, error)
+	GetAllActiveBills() ([]entities.Bill, error)
+	UpdateBill(bill *entities.Bill) (*entities.Bill, error)
+	DeleteBill(id string, userID string) error
+	AddPayment(billID string, userID string, payment entities.BillPayment) (*entities.Bill, error)
+	RemovePayment(billID string, userID string, paymentID string) (*entities.Bill, error)
+	SetLastReminded(billID string, dueDate time.Time) error
+}
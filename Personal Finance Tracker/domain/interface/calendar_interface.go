@@ -0,0 +1,10 @@
+package repositories
+
+import "personal-finance-tracker/domain/entities"
+
+type CalendarFeedRepository interface {
+	// ReplaceFeed stores the feed, dropping any previous feed of the same user
+	ReplaceFeed(feed *entities.CalendarFeed) (*entities.CalendarFeed, error)
+	GetFeedByTokenHash(tokenHash string) (*entities.CalendarFeed, error)
+	DeleteFeed(userID string) error
+}
@@ -0,0 +1,14 @@
+package repositories
+
+import "personal-finance-tracker/domain/entities"
+
+type DebtRepository interface {
+	CreateDebt(debt *entities.Debt) (*entities.Debt, error)
+	GetDebtByID(id string, userID string) (*entities.Debt, error)
+	ListDebts(userID string, page entities.PageRequest) (*entities.Page[entities.Debt], error)
+	GetAllDebts(userID string) ([]entities.Debt, error)
+	UpdateDebt(debt *entities.Debt) (*entities.Debt, error)
+	DeleteDebt(id string, userID string) error
+	AddExtraPayment(debtID string, userID string, payment entities.ExtraPayment) (*entities.Debt, error)
+	RemoveExtraPayment(debtID string, userID string, paymentID string) (*entities.Debt, error)
+}
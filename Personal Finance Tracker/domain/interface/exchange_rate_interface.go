@@ -0,0 +1,13 @@
+package repositories
+
+import (
+	"time"
+
+	"personal-finance-tracker/domain/entities"
+)
+
+type ExchangeRateRepository interface {
+	UpsertRates(rates []entities.ExchangeRate) (int64, error)
+	GetRateOnOrBefore(base, quote string, date time.Time, maxAge time.Duration) (*entities.ExchangeRate, error)
+	ListRates(base, quote string, from, to time.Time, page entities.PageRequest) (*entities.Page[entities.ExchangeRate], error)
+}
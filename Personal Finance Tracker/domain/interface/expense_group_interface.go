@@ -0,0 +1,21 @@
+package repositories
+
+import "personal-finance-tracker/domain/entities"
+
+type ExpenseGroupRepository interface {
+	CreateGroup(group *entities.ExpenseGroup) (*entities.ExpenseGroup, error)
+	GetGroupByID(id string) (*entities.ExpenseGroup, error)
+	ListGroups(userID string, page entities.PageRequest) (*entities.Page[entities.ExpenseGroup], error)
+	UpdateGroup(group *entities.ExpenseGroup) (*entities.ExpenseGroup, error)
+	DeleteGroup(id string) error
+	AddParticipant(groupID string, participant entities.Participant) (*entities.ExpenseGroup, error)
+	RemoveParticipant(groupID string, participantID string) (*entities.ExpenseGroup, error)
+}
+
+type GroupExpenseRepository interface {
+	CreateExpense(expense *entities.GroupExpense) (*entities.GroupExpense, error)
+	ListExpenses(groupID string, page entities.PageRequest) (*entities.Page[entities.GroupExpense], error)
+	GetAllExpenses(groupID string) ([]entities.GroupExpense, error)
+	DeleteExpense(id string, groupID string) error
+	DeleteExpensesByGroup(groupID string) error
+}
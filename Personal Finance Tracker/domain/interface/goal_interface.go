@@ -0,0 +1,22 @@
+package repositories
+
+import "personal-finance-tracker/domain/entities"
+
+type GoalRepository interface {
+	CreateGoal(goal *entities.Goal) (*entities.Goal, error)
+	GetGoalByID(id string, userID string) (*entities.Goal, error)
+	// FindGoalByID looks a goal up by id alone; the caller checks access
+	FindGoalByID(id string) (*entities.Goal, error)
+	ListGoals(userID string, page entities.PageRequest) (*entities.Page[entities.Goal], error)
+	ListHouseholdGoals(householdID string, page entities.PageRequest) (*entities.Page[entities.Goal], error)
+	GetGoalsWithDeadline(userID string) ([]entities.Goal, error)
+	UpdateGoal(goal *entities.Goal) (*entities.Goal, error)
+	DeleteGoal(id string, userID string) error
+	// UnshareGoals stops sharing goals with a household, only those of
+	// userID when it is given
+	UnshareGoals(householdID string, userID string) error
+	// AddContribution and RemoveContribution only apply when the goal stays
+	// at or above zero; otherwise the goal is not found
+	AddContribution(goalID string, userID string, contribution entities.Contribution) (*entities.Goal, error)
+	RemoveContribution(goalID string, userID string, contribution entities.Contribution) (*entities.Goal, error)
+}
@@ -0,0 +1,25 @@
+package repositories
+
+import "personal-finance-tracker/domain/entities"
+
+type HouseholdRepository interface {
+	CreateHousehold(household *entities.Household) (*entities.Household, error)
+	GetHouseholdByID(id string) (*entities.Household, error)
+	ListHouseholds(userID string, page entities.PageRequest) (*entities.Page[entities.Household], error)
+	UpdateHousehold(household *entities.Household) (*entities.Household, error)
+	DeleteHousehold(id string) error
+	AddMember(householdID string, member entities.HouseholdMember) (*entities.Household, error)
+	UpdateMemberRole(householdID string, userID string, role string) (*entities.Household, error)
+	RemoveMember(householdID string, userID string) (*entities.Household, error)
+}
+
+type HouseholdInvitationRepository interface {
+	CreateInvitation(invitation *entities.HouseholdInvitation) (*entities.HouseholdInvitation, error)
+	GetInvitationByTokenHash(tokenHash string) (*entities.HouseholdInvitation, error)
+	ListInvitations(householdID string) ([]entities.HouseholdInvitation, error)
+	DeleteInvitation(id string, householdID string) error
+	// DeleteInvitationsByEmail removes the household's pending invitations
+	// to one email address
+	DeleteInvitationsByEmail(householdID string, email string) error
+	DeleteInvitationsByHousehold(householdID string) error
+}
@@ -0,0 +1,38 @@
+package repositories
+
+import (
+	"time"
+
+	"personal-finance-tracker/domain/entities"
+)
+
+type InvestmentAccountRepository interface {
+	CreateAccount(account *entities.InvestmentAccount) (*entities.InvestmentAccount, error)
+	GetAccountByID(id string, userID string) (*entities.InvestmentAccount, error)
+	ListAccounts(userID string, page entities.PageRequest) (*entities.Page[entities.InvestmentAccount], error)
+	UpdateAccount(account *entities.InvestmentAccount) (*entities.InvestmentAccount, error)
+	DeleteAccount(id string, userID string) error
+}
+
+type InvestmentEventRepository interface {
+	CreateEvent(event *entities.InvestmentEvent) (*entities.InvestmentEvent, error)
+	ListEvents(accountID string, userID string, page entities.PageRequest) (*entities.Page[entities.InvestmentEvent], error)
+	GetAllEvents(accountID string, userID string) ([]entities.InvestmentEvent, error)
+	DeleteEvent(id string, accountID string, userID string) error
+	DeleteEventsByAccount(accountID string, userID string) error
+	GetAllUserEvents(userID string) ([]entities.InvestmentEvent, error)
+}
+
+type PriceRepository interface {
+	UpsertPrices(prices []entities.SecurityPrice) (int64, error)
+	GetPriceOnOrBefore(symbol string, date time.Time) (*entities.SecurityPrice, error)
+	ListPrices(symbol string, from, to time.Time, page entities.PageRequest) (*entities.Page[entities.SecurityPrice], error)
+}
+
+// PerformanceCacheRepository stores computed returns per scope and period
+type PerformanceCacheRepository interface {
+	GetResult(userID string, scope string, accountID string, from, to time.Time) (*entities.PerformanceResult, error)
+	SaveResult(result *entities.PerformanceResult) error
+	InvalidateUser(userID string) error
+	InvalidateAll() error
+}
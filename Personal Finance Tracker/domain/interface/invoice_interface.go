@@ -0,0 +1,31 @@
+package repositories
+
+import (
+	"time"
+
+	"personal-finance-tracker/domain/entities"
+)
+
+type InvoiceRepository interface {
+	CreateInvoice(invoice *entities.Invoice) (*entities.Invoice, error)
+	GetInvoiceByID(id string, userID string) (*entities.Invoice, error)
+	ListInvoices(userID string, status string, page entities.PageRequest) (*entities.Page[entities.Invoice], error)
+	GetOpenInvoices(userID string) ([]entities.Invoice, error)
+	UpdateInvoice(invoice *entities.Invoice) (*entities.Invoice, error)
+	// IssueInvoice saves the number and status of an invoice that is still a
+	// draft; an invoice issued meanwhile is "invoice not found"
+	IssueInvoice(invoice *entities.Invoice) (*entities.Invoice, error)
+	SetInvoiceSentAt(id string, userID string, sentAt time.Time) (*entities.Invoice, error)
+	// VoidInvoice voids a sent invoice without payments; any other invoice
+	// is "invoice not found"
+	VoidInvoice(id string, userID string) (*entities.Invoice, error)
+	DeleteInvoice(id string, userID string) error
+	// AddPayment and RemovePayment set the status and paid date from the
+	// amount due in the same update
+	AddPayment(invoiceID string, userID string, payment entities.InvoicePayment) (*entities.Invoice, error)
+	RemovePayment(invoiceID string, userID string, payment entities.InvoicePayment) (*entities.Invoice, error)
+	NextInvoiceSequence(userID string) (int64, error)
+	// ReleaseInvoiceSequence gives back a number that went unused, if no
+	// later number has been taken since
+	ReleaseInvoiceSequence(userID string, sequence int64) error
+}
@@ -0,0 +1,14 @@
+package repositories
+
+import "personal-finance-tracker/domain/entities"
+
+type PayeeRepository interface {
+	CreatePayee(payee *entities.Payee) (*entities.Payee, error)
+	GetPayeeByID(id string, userID string) (*entities.Payee, error)
+	ListPayees(userID string, page entities.PageRequest) (*entities.Page[entities.Payee], error)
+	GetAllPayees(userID string) ([]entities.Payee, error)
+	UpdatePayee(payee *entities.Payee) (*entities.Payee, error)
+	DeletePayee(id string, userID string) error
+	AddAliases(id string, userID string, aliases []string) (*entities.Payee, error)
+	RemoveAlias(id string, userID string, alias string) (*entities.Payee, error)
+}
@@ -0,0 +1,10 @@
+package repositories
+
+import "personal-finance-tracker/domain/entities"
+
+type ReceiptRepository interface {
+	CreateReceipt(receipt *entities.ParsedReceipt) (*entities.ParsedReceipt, error)
+	GetReceiptByID(id string, userID string) (*entities.ParsedReceipt, error)
+	ListReceipts(userID string, page entities.PageRequest) (*entities.Page[entities.ParsedReceipt], error)
+	DeleteReceipt(id string, userID string) error
+}
@@ -9,4 +9,5 @@ type UserRepository interface {
 	UpdateUser(id string, user *entities.User) (*entities.User, error)
 	DeleteUser(id string) error
 	CountUsers() (int64, error)
+	ListUsers(page entities.PageRequest) (*entities.Page[entities.User], error)
 }